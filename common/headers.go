@@ -1,8 +1,11 @@
 package common
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"runtime"
+	"sync"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +16,38 @@ const (
 	SDK_NAME = "vpc-beta-go-sdk"
 
 	X_REQUEST_ID = "X-Request-Id"
+
+	X_CORRELATION_ID = "X-Correlation-Id"
+)
+
+// HeaderHook - a function that can modify the SDK headers of a request before they are sent
+// (see RegisterHeaderHook).
+//
+// Parameters:
+//
+//	ctx - the context of the request, or context.Background() if the operation was invoked without one
+//	serviceName, serviceVersion, operationId - the parameters of GetSdkHeaders
+//	headers - the headers to modify
+type HeaderHook func(ctx context.Context, serviceName string, serviceVersion string, operationId string, headers map[string]string)
+
+// contextKey is the type of the keys of the values stored by this package in a context.
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	correlationIDKey
+)
+
+// headerHookRegistration is a hook registered with RegisterHeaderHook.
+type headerHookRegistration struct {
+	operationId string
+	hook        HeaderHook
+}
+
+var (
+	headersMutex  sync.RWMutex
+	headerHooks   []*headerHookRegistration
+	userAgentInfo string
 )
 
 // GetSdkHeaders - returns the set of SDK-specific headers to be included in an outgoing request.
@@ -37,19 +72,64 @@ const (
 //
 //	a Map which contains the set of headers to be included in the REST API request
 func GetSdkHeaders(serviceName string, serviceVersion string, operationId string) map[string]string {
+	return GetSdkHeadersWithContext(context.Background(), serviceName, serviceVersion, operationId)
+}
+
+// GetSdkHeadersWithContext - returns the set of SDK-specific headers to be included in an outgoing request,
+// like GetSdkHeaders, taking the request ID and correlation ID from the context (see WithRequestID and
+// WithCorrelationID) and passing the context to the header hooks.
+func GetSdkHeadersWithContext(ctx context.Context, serviceName string, serviceVersion string, operationId string) map[string]string {
 	sdkHeaders := make(map[string]string)
 
 	sdkHeaders[HEADER_NAME_USER_AGENT] = GetUserAgentInfo()
-	sdkHeaders[X_REQUEST_ID] = GetNewXRequestID()
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		sdkHeaders[X_REQUEST_ID] = requestID
+	} else {
+		sdkHeaders[X_REQUEST_ID] = GetNewXRequestID()
+	}
+	if correlationID, ok := CorrelationIDFromContext(ctx); ok {
+		sdkHeaders[X_CORRELATION_ID] = correlationID
+	}
+
+	headersMutex.RLock()
+	registrations := headerHooks
+	headersMutex.RUnlock()
+	for _, all := range []bool{true, false} {
+		for _, registration := range registrations {
+			if (registration.operationId == "") == all && (all || registration.operationId == operationId) {
+				registration.hook(ctx, serviceName, serviceVersion, operationId, sdkHeaders)
+			}
+		}
+	}
 	return sdkHeaders
 }
 
 var UserAgent string = fmt.Sprintf("%s-%s %s", SDK_NAME, Version, GetSystemInfo())
 
 func GetUserAgentInfo() string {
+	headersMutex.RLock()
+	defer headersMutex.RUnlock()
+	if userAgentInfo != "" {
+		return UserAgent + " " + userAgentInfo
+	}
 	return UserAgent
 }
 
+// SetUserAgentApplication - appends the name and version of the application to the User-Agent header
+// (e.g. "my-app/1.2.3"). An empty name removes the suffix.
+func SetUserAgentApplication(appName string, appVersion string) {
+	headersMutex.Lock()
+	defer headersMutex.Unlock()
+	switch {
+	case appName == "":
+		userAgentInfo = ""
+	case appVersion == "":
+		userAgentInfo = appName
+	default:
+		userAgentInfo = appName + "/" + appVersion
+	}
+}
+
 func GetNewXRequestID() string {
 	return uuid.New().String()
 }
@@ -59,3 +139,90 @@ var systemInfo = fmt.Sprintf("(arch=%s; os=%s; go.version=%s)", runtime.GOARCH,
 func GetSystemInfo() string {
 	return systemInfo
 }
+
+// RegisterHeaderHook - registers a hook invoked by GetSdkHeaders for the given operationId (as passed to
+// GetSdkHeaders), or for all operations if operationId is empty. Hooks are invoked in the order in which they
+// were registered, the hooks of all operations first.
+//
+// Returns:
+//
+//	a function that unregisters the hook
+func RegisterHeaderHook(operationId string, hook HeaderHook) (unregister func()) {
+	registration := &headerHookRegistration{operationId: operationId, hook: hook}
+	headersMutex.Lock()
+	defer headersMutex.Unlock()
+	headerHooks = append(headerHooks[:len(headerHooks):len(headerHooks)], registration)
+	return func() {
+		headersMutex.Lock()
+		defer headersMutex.Unlock()
+		remaining := make([]*headerHookRegistration, 0, len(headerHooks))
+		for _, other := range headerHooks {
+			if other != registration {
+				remaining = append(remaining, other)
+			}
+		}
+		headerHooks = remaining
+	}
+}
+
+// WithRequestID - returns a copy of the context carrying the request ID to send in the X-Request-Id header.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext - returns the request ID carried by the context, if any.
+func RequestIDFromContext(ctx context.Context) (requestID string, ok bool) {
+	requestID, ok = ctx.Value(requestIDKey).(string)
+	return
+}
+
+// WithCorrelationID - returns a copy of the context carrying the correlation ID of a logical workflow, to send
+// in the X-Correlation-Id header.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationIDFromContext - returns the correlation ID carried by the context, if any.
+func CorrelationIDFromContext(ctx context.Context) (correlationID string, ok bool) {
+	correlationID, ok = ctx.Value(correlationIDKey).(string)
+	return
+}
+
+// ContextHeadersTransport - an http.RoundTripper that sets the X-Request-Id and X-Correlation-Id headers of
+// requests from their context (see WithRequestID and WithCorrelationID). It applies the IDs to the operations
+// invoked with a context (the ...WithContext methods), which build their headers with GetSdkHeaders:
+//
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: common.NewContextHeadersTransport(nil)})
+//	ctx := common.WithRequestID(context.Background(), "my-request-id")
+//	vpcService.ListVpcsWithContext(ctx, listVpcsOptions)
+type ContextHeadersTransport struct {
+	// The base transport. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+// NewContextHeadersTransport - returns a new ContextHeadersTransport wrapping the given transport.
+func NewContextHeadersTransport(transport http.RoundTripper) *ContextHeadersTransport {
+	return &ContextHeadersTransport{Transport: transport}
+}
+
+// RoundTrip - sends the request with the IDs of its context.
+func (transport *ContextHeadersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := transport.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := req.Context()
+	requestID, hasRequestID := RequestIDFromContext(ctx)
+	correlationID, hasCorrelationID := CorrelationIDFromContext(ctx)
+	if hasRequestID || hasCorrelationID {
+		// A RoundTripper must not modify the request, so the headers are set on a copy.
+		req = req.Clone(ctx)
+		if hasRequestID {
+			req.Header.Set(X_REQUEST_ID, requestID)
+		}
+		if hasCorrelationID {
+			req.Header.Set(X_CORRELATION_ID, correlationID)
+		}
+	}
+	return base.RoundTrip(req)
+}
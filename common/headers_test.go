@@ -1,6 +1,9 @@
 package common
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -26,3 +29,86 @@ func TestGetSdkHeaders(t *testing.T) {
 	_, foundIt = headers[X_REQUEST_ID]
 	assert.True(t, foundIt)
 }
+
+func TestGetSdkHeadersWithContext(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "my-request-id")
+	ctx = WithCorrelationID(ctx, "my-workflow")
+	var headers = GetSdkHeadersWithContext(ctx, "myService", "v123", "myOperation")
+	assert.Equal(t, "my-request-id", headers[X_REQUEST_ID])
+	assert.Equal(t, "my-workflow", headers[X_CORRELATION_ID])
+
+	headers = GetSdkHeadersWithContext(context.Background(), "myService", "v123", "myOperation")
+	assert.NotEmpty(t, headers[X_REQUEST_ID])
+	assert.NotEqual(t, "my-request-id", headers[X_REQUEST_ID])
+	_, foundIt := headers[X_CORRELATION_ID]
+	assert.False(t, foundIt)
+}
+
+func TestSetUserAgentApplication(t *testing.T) {
+	defer SetUserAgentApplication("", "")
+
+	SetUserAgentApplication("my-app", "1.2.3")
+	assert.Equal(t, UserAgent+" my-app/1.2.3", GetSdkHeaders("myService", "v123", "myOperation")[HEADER_NAME_USER_AGENT])
+	SetUserAgentApplication("my-app", "")
+	assert.Equal(t, UserAgent+" my-app", GetUserAgentInfo())
+	SetUserAgentApplication("", "")
+	assert.Equal(t, UserAgent, GetUserAgentInfo())
+}
+
+func TestRegisterHeaderHook(t *testing.T) {
+	var calls []string
+	unregisterOperation := RegisterHeaderHook("myOperation", func(ctx context.Context, serviceName string, serviceVersion string, operationId string, headers map[string]string) {
+		calls = append(calls, "operation")
+		headers["X-Custom"] = headers["X-Custom"] + "-" + operationId
+	})
+	unregisterAll := RegisterHeaderHook("", func(ctx context.Context, serviceName string, serviceVersion string, operationId string, headers map[string]string) {
+		calls = append(calls, "all")
+		headers["X-Custom"] = serviceName
+	})
+
+	var headers = GetSdkHeaders("myService", "v123", "myOperation")
+	assert.Equal(t, []string{"all", "operation"}, calls)
+	assert.Equal(t, "myService-myOperation", headers["X-Custom"])
+
+	calls = nil
+	headers = GetSdkHeaders("myService", "v123", "otherOperation")
+	assert.Equal(t, []string{"all"}, calls)
+	assert.Equal(t, "myService", headers["X-Custom"])
+
+	unregisterOperation()
+	unregisterAll()
+	calls = nil
+	headers = GetSdkHeaders("myService", "v123", "myOperation")
+	assert.Empty(t, calls)
+	_, foundIt := headers["X-Custom"]
+	assert.False(t, foundIt)
+}
+
+func TestContextHeadersTransport(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		received = req.Header.Clone()
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewContextHeadersTransport(nil)}
+
+	ctx := WithCorrelationID(WithRequestID(context.Background(), "my-request-id"), "my-workflow")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.Nil(t, err)
+	req.Header.Set(X_REQUEST_ID, GetNewXRequestID())
+	res, err := client.Do(req)
+	assert.Nil(t, err)
+	res.Body.Close()
+	assert.Equal(t, "my-request-id", received.Get(X_REQUEST_ID))
+	assert.Equal(t, "my-workflow", received.Get(X_CORRELATION_ID))
+	assert.NotEqual(t, "my-request-id", req.Header.Get(X_REQUEST_ID))
+
+	req, err = http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.Nil(t, err)
+	req.Header.Set(X_REQUEST_ID, "generated-id")
+	res, err = client.Do(req)
+	assert.Nil(t, err)
+	res.Body.Close()
+	assert.Equal(t, "generated-id", received.Get(X_REQUEST_ID))
+	assert.Empty(t, received.Get(X_CORRELATION_ID))
+}
@@ -0,0 +1,332 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/vpc-beta-go-sdk/common"
+)
+
+// CassetteMode : The mode of a CassetteTransport.
+type CassetteMode int
+
+const (
+	// CassetteModeRecord sends requests to the base transport and records them.
+	CassetteModeRecord CassetteMode = iota
+
+	// CassetteModeReplay answers requests from the recorded interactions and fails on
+	// requests that were not recorded.
+	CassetteModeReplay
+)
+
+// CassetteRedaction : A pattern to mask in recorded requests and responses.
+type CassetteRedaction struct {
+	// The pattern to mask.
+	Pattern *regexp.Regexp
+
+	// The replacement, which may refer to submatches of the pattern (see regexp.Regexp.ReplaceAllString).
+	Replacement string
+}
+
+// DefaultCassetteRedactions are the redactions applied to every recorded interaction:
+// bearer tokens, IAM tokens and API keys, and IBM Cloud account IDs (including within CRNs).
+var DefaultCassetteRedactions = []*CassetteRedaction{
+	{regexp.MustCompile(`(?i)(bearer\s+)[^\s"]+`), "${1}REDACTED"},
+	{regexp.MustCompile(`"(access_token|refresh_token|apikey|api_key|delegated_refresh_token)"(\s*:\s*)"[^"]*"`), `"$1"$2"REDACTED"`},
+	{regexp.MustCompile(`(crn:v1:[^:"\s]*:[^:"\s]*:[^:"\s]*:[^:"\s]*:a/)[0-9A-Za-z]+`), "${1}REDACTED"},
+	{regexp.MustCompile(`"(account_id)"(\s*:\s*)"[^"]*"`), `"$1"$2"REDACTED"`},
+	{regexp.MustCompile(`("account"\s*:\s*\{\s*"id"\s*:\s*)"[^"]*"`), `$1"REDACTED"`},
+}
+
+// cassetteIgnoredQueryParams are the query parameters ignored when matching requests.
+var cassetteIgnoredQueryParams = []string{"version", "generation"}
+
+// cassetteIgnoredHeaders are the headers that are not recorded.
+var cassetteIgnoredHeaders = []string{"Authorization", "Cookie", "Set-Cookie", common.X_REQUEST_ID}
+
+// CassetteRequest : A recorded request.
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// CassetteResponse : A recorded response.
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// CassetteInteraction : A recorded request and its response.
+type CassetteInteraction struct {
+	Request  *CassetteRequest  `json:"request"`
+	Response *CassetteResponse `json:"response"`
+}
+
+// Cassette : A sequence of recorded interactions.
+type Cassette struct {
+	Interactions []*CassetteInteraction `json:"interactions"`
+}
+
+// LoadCassette reads a cassette from a JSON file.
+func LoadCassette(path string) (cassette *Cassette, err error) {
+	buf, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return
+	}
+	cassette = &Cassette{}
+	err = json.Unmarshal(buf, cassette)
+	return
+}
+
+// Save writes the cassette to a JSON file.
+func (cassette *Cassette) Save(path string) error {
+	buf, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0600)
+}
+
+// CassetteTransport : An http.RoundTripper that records interactions into a Cassette, or
+// replays them from it. To use it, configure it on the service's HTTP client:
+//
+//	transport := vpcbetav1.NewCassetteTransport(cassette, vpcbetav1.CassetteModeReplay, nil)
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: transport})
+//
+// Requests are matched on their method, path and query parameters, ignoring the `version`
+// and `generation` parameters and all headers (so neither `X-Request-Id` nor tokens matter).
+// Identical requests are replayed in the order in which they were recorded.
+type CassetteTransport struct {
+	// The base transport used in record mode. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Redactions applied to recorded interactions in addition to DefaultCassetteRedactions.
+	Redactions []*CassetteRedaction
+
+	cassette *Cassette
+	mode     CassetteMode
+	mutex    sync.Mutex
+	used     map[int]bool
+}
+
+// NewCassetteTransport returns a new CassetteTransport using the given cassette.
+func NewCassetteTransport(cassette *Cassette, mode CassetteMode, transport http.RoundTripper) *CassetteTransport {
+	if cassette == nil {
+		cassette = &Cassette{}
+	}
+	return &CassetteTransport{
+		Transport: transport,
+		cassette:  cassette,
+		mode:      mode,
+		used:      make(map[int]bool),
+	}
+}
+
+// Cassette returns the cassette of the transport, holding the interactions recorded so far.
+func (transport *CassetteTransport) Cassette() *Cassette {
+	return transport.cassette
+}
+
+// RoundTrip records or replays a single request.
+func (transport *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if transport.mode == CassetteModeReplay {
+		return transport.replay(req)
+	}
+	return transport.record(req)
+}
+
+// record sends the request to the base transport and records the interaction.
+func (transport *CassetteTransport) record(req *http.Request) (*http.Response, error) {
+	req, reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	base := transport.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	interaction := &CassetteInteraction{
+		Request: &CassetteRequest{
+			Method:  req.Method,
+			URL:     transport.redact(req.URL.String()),
+			Headers: transport.redactHeaders(req.Header),
+			Body:    transport.redact(string(reqBody)),
+		},
+		Response: &CassetteResponse{
+			StatusCode: res.StatusCode,
+			Headers:    transport.redactHeaders(res.Header),
+			Body:       transport.redact(string(resBody)),
+		},
+	}
+	transport.mutex.Lock()
+	transport.cassette.Interactions = append(transport.cassette.Interactions, interaction)
+	transport.mutex.Unlock()
+	return res, nil
+}
+
+// replay answers the request with the first unused matching interaction.
+func (transport *CassetteTransport) replay(req *http.Request) (*http.Response, error) {
+	closeRequestBody(req)
+	// The recorded URLs are redacted, so the request URL must be too before matching.
+	requestURL, err := url.Parse(transport.redact(req.URL.String()))
+	if err != nil {
+		return nil, err
+	}
+	key := cassetteKey(req.Method, requestURL)
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	for i, interaction := range transport.cassette.Interactions {
+		if transport.used[i] {
+			continue
+		}
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil || cassetteKey(interaction.Request.Method, recordedURL) != key {
+			continue
+		}
+		transport.used[i] = true
+
+		header := http.Header{}
+		for name, values := range interaction.Response.Headers {
+			header[name] = append([]string(nil), values...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette: no recorded interaction matches the request %s", key)
+}
+
+// redact applies the redactions to a recorded string.
+func (transport *CassetteTransport) redact(s string) string {
+	for _, redactions := range [][]*CassetteRedaction{DefaultCassetteRedactions, transport.Redactions} {
+		for _, redaction := range redactions {
+			s = redaction.Pattern.ReplaceAllString(s, redaction.Replacement)
+		}
+	}
+	return s
+}
+
+// redactHeaders returns a copy of the headers without the ignored ones, with redactions applied.
+func (transport *CassetteTransport) redactHeaders(headers http.Header) http.Header {
+	redacted := http.Header{}
+	for name, values := range headers {
+		ignored := false
+		for _, ignoredName := range cassetteIgnoredHeaders {
+			if strings.EqualFold(name, ignoredName) {
+				ignored = true
+			}
+		}
+		if ignored {
+			continue
+		}
+		for _, value := range values {
+			redacted.Add(name, transport.redact(value))
+		}
+	}
+	return redacted
+}
+
+// cassetteKey returns the string used to match a request with a recorded interaction.
+func cassetteKey(method string, u *url.URL) string {
+	query := u.Query()
+	for _, param := range cassetteIgnoredQueryParams {
+		query.Del(param)
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+	key := method + " " + u.Path
+	if len(params) > 0 {
+		key += "?" + strings.Join(params, "&")
+	}
+	return key
+}
+
+// readRequestBody reads the request body and returns it along with a clone of the request
+// that can be sent in place of the original, which is left as is (but for its body being
+// closed). The body is read from GetBody when it is set.
+func readRequestBody(req *http.Request) (clone *http.Request, body []byte, err error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil, nil
+	}
+	if req.GetBody != nil {
+		var reader io.ReadCloser
+		if reader, err = req.GetBody(); err != nil {
+			closeRequestBody(req)
+			return
+		}
+		body, err = io.ReadAll(reader)
+		_ = reader.Close()
+		closeRequestBody(req)
+	} else {
+		body, err = io.ReadAll(req.Body)
+		closeRequestBody(req)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	clone = req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return
+}
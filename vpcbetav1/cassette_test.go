@@ -0,0 +1,138 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`CassetteTransport`, func() {
+	It(`Records and replays interactions`, func() {
+		server := vpcfake.NewServer()
+		service, err := core.NewBaseService(&core.ServiceOptions{
+			URL:           server.ServiceURL(),
+			Authenticator: &core.BearerTokenAuthenticator{BearerToken: "secret-token"},
+		})
+		Expect(err).To(BeNil())
+
+		recorder := vpcbetav1.NewCassetteTransport(nil, vpcbetav1.CassetteModeRecord, nil)
+		recorder.Redactions = []*vpcbetav1.CassetteRedaction{
+			{Pattern: regexp.MustCompile(`my-secret-name`), Replacement: "my-name"},
+		}
+		service.SetHTTPClient(&http.Client{Transport: recorder})
+
+		vpc, _, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{
+			"name": "my-secret-name",
+			"resource_group": map[string]interface{}{
+				"crn": "crn:v1:bluemix:public:resource-controller::a/123456789abcdef::resource-group:fee82deba12e4c0fb69c3b09d1f12345",
+			},
+		})
+		Expect(err).To(BeNil())
+		vpcPath := fmt.Sprintf("/vpcs/%s", vpc["id"])
+		first, _, err := invoke(service, core.GET, vpcPath, nil)
+		Expect(err).To(BeNil())
+		second, _, err := invoke(service, core.GET, vpcPath, nil)
+		Expect(err).To(BeNil())
+		server.Close()
+
+		cassette := recorder.Cassette()
+		Expect(cassette.Interactions).To(HaveLen(3))
+		for _, interaction := range cassette.Interactions {
+			Expect(interaction.Request.Headers.Get("Authorization")).To(BeEmpty())
+			Expect(interaction.Request.Headers.Get("X-Request-Id")).To(BeEmpty())
+			Expect(interaction.Request.Body).ToNot(ContainSubstring("123456789abcdef"))
+			Expect(interaction.Response.Body).ToNot(ContainSubstring("my-secret-name"))
+		}
+		Expect(cassette.Interactions[0].Request.Body).To(ContainSubstring("a/REDACTED"))
+
+		dir, err := os.MkdirTemp("", "cassette")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "cassette.json")
+		Expect(cassette.Save(path)).To(Succeed())
+		cassette, err = vpcbetav1.LoadCassette(path)
+		Expect(err).To(BeNil())
+		Expect(cassette.Interactions).To(HaveLen(3))
+
+		// Replay with a different version and without a server.
+		player := vpcbetav1.NewCassetteTransport(cassette, vpcbetav1.CassetteModeReplay, nil)
+		service.SetHTTPClient(&http.Client{Transport: player})
+		replayed, response, err := invoke(service, core.GET, vpcPath+"?version=2099-01-01", nil)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(replayed["status"]).To(Equal(first["status"]))
+		replayed, _, err = invoke(service, core.GET, vpcPath, nil)
+		Expect(err).To(BeNil())
+		Expect(replayed["status"]).To(Equal(second["status"]))
+
+		_, _, err = invoke(service, core.GET, vpcPath, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("no recorded interaction"))
+		_, _, err = invoke(service, core.GET, "/subnets", nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Does not modify the requests it records`, func() {
+		server := vpcfake.NewServer()
+		defer server.Close()
+		recorder := vpcbetav1.NewCassetteTransport(nil, vpcbetav1.CassetteModeRecord, nil)
+
+		request, err := http.NewRequest(http.MethodPost, server.ServiceURL()+"/vpcs?version=2024-03-12&generation=2", strings.NewReader(`{"name":"my-vpc"}`))
+		Expect(err).To(BeNil())
+		request.Header.Set("Content-Type", "application/json")
+		body, getBody := request.Body, request.GetBody
+		response, err := recorder.RoundTrip(request)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		Expect(request.Body).To(BeIdenticalTo(body))
+		Expect(request.GetBody).ToNot(BeNil())
+		Expect(fmt.Sprintf("%p", request.GetBody)).To(Equal(fmt.Sprintf("%p", getBody)))
+		Expect(recorder.Cassette().Interactions[0].Request.Body).To(Equal(`{"name":"my-vpc"}`))
+
+		// The body is read from GetBody when it is set, even if the request body was consumed.
+		request, err = http.NewRequest(http.MethodPost, server.ServiceURL()+"/vpcs?version=2024-03-12&generation=2", strings.NewReader(`{"name":"other-vpc"}`))
+		Expect(err).To(BeNil())
+		_, err = io.ReadAll(request.Body)
+		Expect(err).To(BeNil())
+		_, err = recorder.RoundTrip(request)
+		Expect(err).To(BeNil())
+		Expect(recorder.Cassette().Interactions[1].Request.Body).To(Equal(`{"name":"other-vpc"}`))
+	})
+	It(`Invoke LoadCassette with error`, func() {
+		dir, err := os.MkdirTemp("", "cassette")
+		Expect(err).To(BeNil())
+		defer os.RemoveAll(dir)
+		_, err = vpcbetav1.LoadCassette(filepath.Join(dir, "missing.json"))
+		Expect(err).ToNot(BeNil())
+
+		path := filepath.Join(dir, "invalid.json")
+		Expect(os.WriteFile(path, []byte("not json"), 0600)).To(Succeed())
+		_, err = vpcbetav1.LoadCassette(path)
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,361 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"sort"
+	"sync"
+)
+
+// MaxSubnetPrefixLength is the longest prefix length of a subnet (8 addresses).
+const MaxSubnetPrefixLength = 29
+
+// ipv4Range is an inclusive range of IPv4 addresses.
+type ipv4Range struct {
+	first, last uint32
+}
+
+// overlaps returns true if the ranges have an address in common.
+func (r ipv4Range) overlaps(other ipv4Range) bool {
+	return r.first <= other.last && other.first <= r.last
+}
+
+// parseIPv4Range parses an IPv4 CIDR block.
+func parseIPv4Range(cidr string) (r ipv4Range, err error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return
+	}
+	if !prefix.Addr().Is4() || prefix.Masked() != prefix {
+		err = fmt.Errorf("%s is not an IPv4 CIDR block", cidr)
+		return
+	}
+	return prefixRange(prefix), nil
+}
+
+// prefixRange returns the range of addresses of a prefix.
+func prefixRange(prefix netip.Prefix) ipv4Range {
+	addr := prefix.Addr().As4()
+	first := uint32(addr[0])<<24 | uint32(addr[1])<<16 | uint32(addr[2])<<8 | uint32(addr[3])
+	return ipv4Range{first: first, last: first | uint32(uint64(1)<<(32-prefix.Bits())-1)}
+}
+
+// ipv4Addr returns an IPv4 address.
+func ipv4Addr(address uint32) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(address >> 24), byte(address >> 16), byte(address >> 8), byte(address)})
+}
+
+// rangeCIDR returns the CIDR block of the given first address and prefix length.
+func rangeCIDR(first uint32, prefixLength int) string {
+	return netip.PrefixFrom(ipv4Addr(first), prefixLength).String()
+}
+
+// CIDRPlanner : A planner of the IPv4 CIDR blocks of the address prefixes and subnets of a VPC.
+// It models the address prefixes of each zone, the CIDR blocks already used by subnets and the
+// CIDR blocks to avoid (such as the peer networks of VPN gateways), and allocates the next free
+// CIDR block of a requested size.
+//
+// Allocated CIDR blocks are marked as used, so that successive allocations do not collide.
+// A CIDRPlanner is safe for concurrent use.
+type CIDRPlanner struct {
+	mutex      sync.Mutex
+	prefixes   map[string][]ipv4Range
+	used       []ipv4Range
+	exclusions []ipv4Range
+}
+
+// NewCIDRPlanner returns a new, empty CIDRPlanner avoiding the given CIDR blocks.
+func NewCIDRPlanner(exclusions ...string) (planner *CIDRPlanner, err error) {
+	planner = &CIDRPlanner{prefixes: make(map[string][]ipv4Range)}
+	for _, cidr := range exclusions {
+		if err = planner.Exclude(cidr); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// LoadCIDRPlanner returns a new CIDRPlanner modeling the address prefixes and subnets of a VPC,
+// retrieved with the given List operations (see CollectionListFunc): usually wrappers around
+// ListVPCAddressPrefixesWithContext and ListSubnetsWithContext, filtered by VPC. P and S are
+// the types of the address prefixes and subnets (the AddressPrefix and Subnet models).
+func LoadCIDRPlanner[P, S any](ctx context.Context, listAddressPrefixes CollectionListFunc, listSubnets CollectionListFunc, exclusions ...string) (planner *CIDRPlanner, err error) {
+	if planner, err = NewCIDRPlanner(exclusions...); err != nil {
+		return
+	}
+	CollectionItems[P](ctx, listAddressPrefixes, nil)(func(addressPrefix P, itemErr error) bool {
+		var zone, cidr string
+		if err = itemErr; err == nil {
+			if zone, cidr, err = zoneCIDROf(addressPrefix, "cidr"); err == nil {
+				err = planner.AddAddressPrefix(zone, cidr)
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	CollectionItems[S](ctx, listSubnets, nil)(func(subnet S, itemErr error) bool {
+		var cidr string
+		if err = itemErr; err == nil {
+			if _, cidr, err = zoneCIDROf(subnet, "ipv4_cidr_block"); err == nil {
+				err = planner.Use(cidr)
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// zoneCIDROf returns the zone name and the CIDR block property of a model.
+func zoneCIDROf(model interface{}, property string) (zone string, cidr string, err error) {
+	object, err := ToJSONObject(model)
+	if err != nil {
+		return
+	}
+	if zoneObject, ok := object["zone"].(map[string]interface{}); ok {
+		zone, _ = zoneObject["name"].(string)
+	}
+	cidr, _ = object[property].(string)
+	if cidr == "" {
+		err = fmt.Errorf("%T has no %s", model, property)
+	}
+	return
+}
+
+// AddAddressPrefix adds an address prefix of a zone.
+func (planner *CIDRPlanner) AddAddressPrefix(zone string, cidr string) error {
+	r, err := parseIPv4Range(cidr)
+	if err != nil {
+		return err
+	}
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	planner.prefixes[zone] = append(planner.prefixes[zone], r)
+	sort.Slice(planner.prefixes[zone], func(i, j int) bool {
+		return planner.prefixes[zone][i].first < planner.prefixes[zone][j].first
+	})
+	return nil
+}
+
+// Use marks a CIDR block as used, such as the CIDR block of an existing subnet.
+func (planner *CIDRPlanner) Use(cidr string) error {
+	r, err := parseIPv4Range(cidr)
+	if err != nil {
+		return err
+	}
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	planner.used = append(planner.used, r)
+	return nil
+}
+
+// Release marks a CIDR block marked as used as free again, such as the CIDR block of a
+// deleted subnet.
+func (planner *CIDRPlanner) Release(cidr string) error {
+	r, err := parseIPv4Range(cidr)
+	if err != nil {
+		return err
+	}
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	for i, used := range planner.used {
+		if used == r {
+			planner.used = append(planner.used[:i], planner.used[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not used", cidr)
+}
+
+// Exclude marks a CIDR block to avoid, such as the CIDR block of a peer network.
+func (planner *CIDRPlanner) Exclude(cidr string) error {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return err
+	}
+	if !prefix.Addr().Is4() {
+		return fmt.Errorf("%s is not an IPv4 CIDR block", cidr)
+	}
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	planner.exclusions = append(planner.exclusions, prefixRange(prefix.Masked()))
+	return nil
+}
+
+// Zones returns the names of the zones with address prefixes, sorted.
+func (planner *CIDRPlanner) Zones() (zones []string) {
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	for zone := range planner.prefixes {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return
+}
+
+// FreeSpace returns the free CIDR blocks of the address prefixes of a zone: the largest CIDR
+// blocks that neither overlap a used CIDR block nor an excluded one, in address order.
+func (planner *CIDRPlanner) FreeSpace(zone string) (free []string) {
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	blocked := planner.blocked(nil)
+	for _, prefix := range planner.prefixes[zone] {
+		next := uint64(prefix.first)
+		for _, r := range blocked {
+			if r.last < prefix.first || r.first > prefix.last {
+				continue
+			}
+			if uint64(r.first) > next {
+				free = append(free, rangeCIDRs(uint32(next), r.first-1)...)
+			}
+			if uint64(r.last)+1 > next {
+				next = uint64(r.last) + 1
+			}
+		}
+		if next <= uint64(prefix.last) {
+			free = append(free, rangeCIDRs(uint32(next), prefix.last)...)
+		}
+	}
+	return
+}
+
+// rangeCIDRs returns the smallest list of CIDR blocks covering a range of addresses.
+func rangeCIDRs(first uint32, last uint32) (cidrs []string) {
+	for next := uint64(first); next <= uint64(last); {
+		// The largest block aligned on next and not going past last.
+		size := 32
+		if next != 0 {
+			size = bits.TrailingZeros32(uint32(next))
+		}
+		for size > 0 && next+(uint64(1)<<size)-1 > uint64(last) {
+			size--
+		}
+		cidrs = append(cidrs, rangeCIDR(uint32(next), 32-size))
+		next += uint64(1) << size
+	}
+	return
+}
+
+// NextSubnet allocates the first free CIDR block with the given prefix length (at most
+// MaxSubnetPrefixLength) in the address prefixes of a zone, and marks it as used.
+// The result is the Ipv4CIDRBlock of a new subnet of the zone.
+func (planner *CIDRPlanner) NextSubnet(zone string, prefixLength int) (cidr string, err error) {
+	if prefixLength < 1 || prefixLength > MaxSubnetPrefixLength {
+		err = fmt.Errorf("prefix length must be between 1 and %d, not %d", MaxSubnetPrefixLength, prefixLength)
+		return
+	}
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	prefixes := planner.prefixes[zone]
+	if len(prefixes) == 0 {
+		err = fmt.Errorf("zone %s has no address prefixes", zone)
+		return
+	}
+	r, found := firstFreeRange(prefixes, planner.blocked(nil), prefixLength)
+	if !found {
+		err = fmt.Errorf("no free /%d CIDR block in the address prefixes of zone %s", prefixLength, zone)
+		return
+	}
+	planner.used = append(planner.used, r)
+	return rangeCIDR(r.first, prefixLength), nil
+}
+
+// NextSubnetForCount is NextSubnet for the given TotalIpv4AddressCount of a subnet: a power
+// of 2 of at least 8.
+func (planner *CIDRPlanner) NextSubnetForCount(zone string, totalIpv4AddressCount int64) (cidr string, err error) {
+	if totalIpv4AddressCount < 8 || totalIpv4AddressCount > 1<<31 || bits.OnesCount64(uint64(totalIpv4AddressCount)) != 1 {
+		err = fmt.Errorf("total IPv4 address count must be a power of 2 of at least 8, not %d", totalIpv4AddressCount)
+		return
+	}
+	return planner.NextSubnet(zone, 32-bits.TrailingZeros64(uint64(totalIpv4AddressCount)))
+}
+
+// NextAddressPrefix allocates the first free CIDR block with the given prefix length within
+// the given CIDR block (such as 10.0.0.0/8), and adds it as an address prefix of a zone.
+// The CIDR block neither overlaps the address prefixes of any zone, nor a used or excluded
+// CIDR block. The result is the CIDR of a new address prefix of the zone.
+func (planner *CIDRPlanner) NextAddressPrefix(zone string, prefixLength int, within string) (cidr string, err error) {
+	if prefixLength < 1 || prefixLength > MaxSubnetPrefixLength {
+		err = fmt.Errorf("prefix length must be between 1 and %d, not %d", MaxSubnetPrefixLength, prefixLength)
+		return
+	}
+	space, err := parseIPv4Range(within)
+	if err != nil {
+		return
+	}
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+	var prefixes []ipv4Range
+	for _, zonePrefixes := range planner.prefixes {
+		prefixes = append(prefixes, zonePrefixes...)
+	}
+	r, found := firstFreeRange([]ipv4Range{space}, planner.blocked(prefixes), prefixLength)
+	if !found {
+		err = fmt.Errorf("no free /%d CIDR block in %s", prefixLength, within)
+		return
+	}
+	planner.prefixes[zone] = append(planner.prefixes[zone], r)
+	sort.Slice(planner.prefixes[zone], func(i, j int) bool {
+		return planner.prefixes[zone][i].first < planner.prefixes[zone][j].first
+	})
+	return rangeCIDR(r.first, prefixLength), nil
+}
+
+// blocked returns the used and excluded ranges, and the given ones, sorted. The caller must
+// hold the mutex.
+func (planner *CIDRPlanner) blocked(others []ipv4Range) []ipv4Range {
+	blocked := make([]ipv4Range, 0, len(planner.used)+len(planner.exclusions)+len(others))
+	blocked = append(blocked, planner.used...)
+	blocked = append(blocked, planner.exclusions...)
+	blocked = append(blocked, others...)
+	sort.Slice(blocked, func(i, j int) bool { return blocked[i].first < blocked[j].first })
+	return blocked
+}
+
+// firstFreeRange returns the first block with the given prefix length inside the given
+// (sorted) ranges that overlaps none of the (sorted) blocked ranges.
+func firstFreeRange(ranges []ipv4Range, blocked []ipv4Range, prefixLength int) (r ipv4Range, found bool) {
+	size := uint64(1) << (32 - prefixLength)
+	for _, space := range ranges {
+		// The first aligned block of the range.
+		start := (uint64(space.first) + size - 1) / size * size
+		for start+size-1 <= uint64(space.last) {
+			candidate := ipv4Range{first: uint32(start), last: uint32(start + size - 1)}
+			var overlap *ipv4Range
+			for i := range blocked {
+				if blocked[i].overlaps(candidate) {
+					overlap = &blocked[i]
+					break
+				}
+			}
+			if overlap == nil {
+				return candidate, true
+			}
+			// Skip to the first aligned block after the overlapping range.
+			start = (uint64(overlap.last) + size) / size * size
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testAddressPrefix, testCIDRSubnet and their collections mirror the shape of the generated
+// models.
+type testZoneReference struct {
+	Name *string `json:"name" validate:"required"`
+}
+
+type testAddressPrefix struct {
+	CIDR *string            `json:"cidr" validate:"required"`
+	Zone *testZoneReference `json:"zone" validate:"required"`
+}
+
+type testAddressPrefixCollection struct {
+	AddressPrefixes []testAddressPrefix `json:"address_prefixes"`
+}
+
+type testCIDRSubnet struct {
+	Ipv4CIDRBlock *string            `json:"ipv4_cidr_block" validate:"required"`
+	Zone          *testZoneReference `json:"zone" validate:"required"`
+}
+
+type testCIDRSubnetCollection struct {
+	Subnets []testCIDRSubnet `json:"subnets"`
+}
+
+var _ = Describe(`CIDRPlanner`, func() {
+	var planner *vpcbetav1.CIDRPlanner
+
+	BeforeEach(func() {
+		listAddressPrefixes := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			return &testAddressPrefixCollection{AddressPrefixes: []testAddressPrefix{
+				{CIDR: core.StringPtr("10.240.0.0/18"), Zone: &testZoneReference{Name: core.StringPtr("us-south-1")}},
+				{CIDR: core.StringPtr("10.240.64.0/18"), Zone: &testZoneReference{Name: core.StringPtr("us-south-2")}},
+			}}, nil, nil
+		}
+		listSubnets := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			return &testCIDRSubnetCollection{Subnets: []testCIDRSubnet{
+				{Ipv4CIDRBlock: core.StringPtr("10.240.0.0/24"), Zone: &testZoneReference{Name: core.StringPtr("us-south-1")}},
+				{Ipv4CIDRBlock: core.StringPtr("10.240.2.0/24"), Zone: &testZoneReference{Name: core.StringPtr("us-south-1")}},
+			}}, nil, nil
+		}
+		var err error
+		planner, err = vpcbetav1.LoadCIDRPlanner[testAddressPrefix, testCIDRSubnet](context.Background(),
+			listAddressPrefixes, listSubnets, "10.240.4.0/22")
+		Expect(err).To(BeNil())
+	})
+
+	It(`Models the free space of each zone`, func() {
+		Expect(planner.Zones()).To(Equal([]string{"us-south-1", "us-south-2"}))
+		Expect(planner.FreeSpace("us-south-1")).To(Equal([]string{
+			"10.240.1.0/24", "10.240.3.0/24", "10.240.8.0/21", "10.240.16.0/20", "10.240.32.0/19",
+		}))
+		Expect(planner.FreeSpace("us-south-2")).To(Equal([]string{"10.240.64.0/18"}))
+		Expect(planner.FreeSpace("us-south-3")).To(BeEmpty())
+	})
+	It(`Allocates the next free subnets`, func() {
+		Expect(planner.NextSubnet("us-south-1", 24)).To(Equal("10.240.1.0/24"))
+		Expect(planner.NextSubnet("us-south-1", 24)).To(Equal("10.240.3.0/24"))
+		// 10.240.4.0/22 is excluded.
+		Expect(planner.NextSubnet("us-south-1", 24)).To(Equal("10.240.8.0/24"))
+		Expect(planner.NextSubnetForCount("us-south-1", 2048)).To(Equal("10.240.16.0/21"))
+		Expect(planner.NextSubnetForCount("us-south-1", 8)).To(Equal("10.240.9.0/29"))
+
+		Expect(planner.Release("10.240.3.0/24")).To(Succeed())
+		Expect(planner.NextSubnet("us-south-1", 25)).To(Equal("10.240.3.0/25"))
+
+		_, err := planner.NextSubnet("us-south-1", 17)
+		Expect(err).ToNot(BeNil())
+		_, err = planner.NextSubnet("us-south-3", 24)
+		Expect(err).ToNot(BeNil())
+		_, err = planner.NextSubnetForCount("us-south-1", 100)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Allocates the next free address prefixes`, func() {
+		Expect(planner.NextAddressPrefix("us-south-3", 18, "10.240.0.0/16")).To(Equal("10.240.128.0/18"))
+		Expect(planner.Exclude("10.240.192.0/20")).To(Succeed())
+		_, err := planner.NextAddressPrefix("us-south-3", 18, "10.240.0.0/16")
+		Expect(err).ToNot(BeNil())
+		Expect(planner.NextAddressPrefix("us-south-3", 20, "10.240.0.0/16")).To(Equal("10.240.208.0/20"))
+		Expect(planner.NextSubnet("us-south-3", 24)).To(Equal("10.240.128.0/24"))
+	})
+	It(`Rejects invalid CIDR blocks`, func() {
+		Expect(planner.AddAddressPrefix("us-south-1", "10.240.0.1/18")).ToNot(Succeed())
+		Expect(planner.Use("fe80::/64")).ToNot(Succeed())
+		_, err := vpcbetav1.NewCIDRPlanner("not-a-cidr")
+		Expect(err).ToNot(BeNil())
+	})
+})
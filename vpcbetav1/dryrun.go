@@ -0,0 +1,178 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/vpc-beta-go-sdk/common"
+)
+
+// DryRunIDPrefix is the prefix of the IDs of the resources "created" in dry-run mode.
+const DryRunIDPrefix = "dry-run-"
+
+// DryRunCall : A mutating call recorded by a DryRunTransport.
+type DryRunCall struct {
+	// The operation ID of the call (see OperationID), such as `create_instance`.
+	Operation string `json:"operation"`
+
+	// The HTTP method and path of the call.
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// The JSON body of the call, if any. Bodies that are not JSON are recorded as strings.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// DryRunTransport : An http.RoundTripper that lets read-only (GET, HEAD and OPTIONS) requests
+// through, but short-circuits mutating requests: it records them into a plan and answers them
+// with synthetic responses. To use it, wrap the transport of the service's HTTP client:
+//
+//	dryRun := vpcbetav1.NewDryRunTransport(nil)
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: dryRun})
+//	// Run the automation, then:
+//	plan, _ := dryRun.PlanJSON()
+//
+// The synthetic response of a create or action is the request body with an `id` and an
+// `href`; the response of an update or replace is the request body with the `id` of the
+// resource; the response of a delete is 204 No Content.
+type DryRunTransport struct {
+	// The base transport for read-only requests. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	mutex sync.Mutex
+	calls []*DryRunCall
+}
+
+// NewDryRunTransport returns a new DryRunTransport wrapping the given transport.
+func NewDryRunTransport(transport http.RoundTripper) *DryRunTransport {
+	return &DryRunTransport{Transport: transport}
+}
+
+// Plan returns the calls recorded so far, in order.
+func (transport *DryRunTransport) Plan() []*DryRunCall {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	return append([]*DryRunCall(nil), transport.calls...)
+}
+
+// PlanJSON returns the calls recorded so far as an indented JSON document of the form
+// `{"calls": [...]}`.
+func (transport *DryRunTransport) PlanJSON() ([]byte, error) {
+	return json.MarshalIndent(map[string]interface{}{"calls": transport.Plan()}, "", "  ")
+}
+
+// Reset clears the recorded calls.
+func (transport *DryRunTransport) Reset() {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	transport.calls = nil
+}
+
+// RoundTrip sends read-only requests and records the others.
+func (transport *DryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		base := transport.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		return base.RoundTrip(req)
+	}
+
+	_, body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	call := &DryRunCall{
+		Operation: operationIDOf(req),
+		Method:    req.Method,
+		Path:      req.URL.Path,
+	}
+	if len(body) > 0 {
+		if json.Valid(body) {
+			compact := &bytes.Buffer{}
+			_ = json.Compact(compact, body)
+			call.Body = compact.Bytes()
+		} else {
+			call.Body, _ = json.Marshal(string(body))
+		}
+	}
+
+	transport.mutex.Lock()
+	transport.calls = append(transport.calls, call)
+	sequence := len(transport.calls)
+	transport.mutex.Unlock()
+
+	return dryRunResponse(req, body, sequence), nil
+}
+
+// dryRunResponse returns the synthetic response of a mutating request.
+func dryRunResponse(req *http.Request, body []byte, sequence int) *http.Response {
+	statusCode := http.StatusOK
+	var result map[string]interface{}
+	if req.Method != http.MethodDelete {
+		if err := json.Unmarshal(body, &result); err != nil || result == nil {
+			result = make(map[string]interface{})
+		}
+	}
+
+	segments := strings.FieldsFunc(req.URL.Path, func(r rune) bool { return r == '/' })
+	switch {
+	case req.Method == http.MethodDelete:
+		statusCode = http.StatusNoContent
+	case req.Method == http.MethodPost:
+		// A create or an action: a new resource in the collection of the path.
+		statusCode = http.StatusCreated
+		id := fmt.Sprintf("%s%d", DryRunIDPrefix, sequence)
+		result["id"] = id
+		result["href"] = strings.TrimSuffix(req.URL.Scheme+"://"+req.URL.Host+req.URL.Path, "/") + "/" + id
+		result["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	case len(segments) > 0:
+		if _, ok := result["id"]; !ok {
+			result["id"] = segments[len(segments)-1]
+		}
+	}
+
+	header := http.Header{}
+	if requestID := req.Header.Get(common.X_REQUEST_ID); requestID != "" {
+		header.Set(common.X_REQUEST_ID, requestID)
+	}
+	var resBody []byte
+	if result != nil {
+		header.Set("Content-Type", "application/json")
+		resBody, _ = json.Marshal(result)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resBody)),
+		ContentLength: int64(len(resBody)),
+		Request:       req,
+	}
+}
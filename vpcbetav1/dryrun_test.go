@@ -0,0 +1,103 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`DryRunTransport`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var dryRun *vpcbetav1.DryRunTransport
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		dryRun = vpcbetav1.NewDryRunTransport(nil)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Records mutating calls and lets reads through`, func() {
+		vpc, _, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		vpcPath := fmt.Sprintf("/vpcs/%s", vpc["id"])
+		service.SetHTTPClient(&http.Client{Transport: dryRun})
+
+		result, response, err := invoke(service, core.GET, vpcPath, nil)
+		Expect(err).To(BeNil())
+		Expect(result["name"]).To(Equal("my-vpc"))
+
+		subnet, response, err := invoke(service, core.POST, "/subnets", map[string]interface{}{
+			"name": "my-subnet", "vpc": map[string]interface{}{"id": vpc["id"]},
+		}, "X-Request-Id", "req-1")
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		Expect(response.Headers.Get("X-Request-Id")).To(Equal("req-1"))
+		Expect(subnet["id"]).To(Equal(vpcbetav1.DryRunIDPrefix + "1"))
+		Expect(subnet["name"]).To(Equal("my-subnet"))
+		Expect(subnet["href"]).To(HaveSuffix("/v1/subnets/" + vpcbetav1.DryRunIDPrefix + "1"))
+
+		updated, response, err := invoke(service, core.PATCH, vpcPath, map[string]interface{}{"name": "my-vpc-updated"})
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(updated).To(Equal(map[string]interface{}{"id": vpc["id"], "name": "my-vpc-updated"}))
+
+		_, response, err = invoke(service, core.DELETE, vpcPath, nil)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+		// Nothing was changed.
+		result, _, err = invoke(service, core.GET, vpcPath, nil)
+		Expect(err).To(BeNil())
+		Expect(result["name"]).To(Equal("my-vpc"))
+		result, _, err = invoke(service, core.GET, "/subnets", nil)
+		Expect(err).To(BeNil())
+		Expect(result["subnets"]).To(BeEmpty())
+
+		plan := dryRun.Plan()
+		Expect(plan).To(HaveLen(3))
+		Expect(plan[0].Operation).To(Equal("create_subnet"))
+		Expect(plan[0].Method).To(Equal("POST"))
+		Expect(plan[0].Path).To(Equal("/v1/subnets"))
+		Expect(plan[1].Operation).To(Equal("update_vpc"))
+		Expect(string(plan[1].Body)).To(Equal(`{"name":"my-vpc-updated"}`))
+		Expect(plan[2].Operation).To(Equal("delete_vpc"))
+		Expect(plan[2].Body).To(BeNil())
+
+		buf, err := dryRun.PlanJSON()
+		Expect(err).To(BeNil())
+		var dumped map[string][]map[string]interface{}
+		Expect(json.Unmarshal(buf, &dumped)).To(Succeed())
+		Expect(dumped["calls"]).To(HaveLen(3))
+		Expect(dumped["calls"][0]["body"]).To(HaveKeyWithValue("name", "my-subnet"))
+		Expect(dumped["calls"][2]).ToNot(HaveKey("body"))
+
+		dryRun.Reset()
+		Expect(dryRun.Plan()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,215 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/common"
+)
+
+// APIError : An error response returned by the VPC API.
+// Use NewAPIError to build it from the results of an operation, then errors.As or the
+// Is... helpers to inspect it.
+type APIError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+
+	// The errors reported in the response body.
+	Errors []APIErrorItem
+
+	// The trace ID reported in the response body, to provide to IBM Cloud support.
+	Trace string
+
+	// The `X-Request-Id` of the request, as echoed in the response headers.
+	RequestID string
+
+	// The detailed response of the operation.
+	Response *core.DetailedResponse
+
+	// The error returned by the operation.
+	Err error
+}
+
+// APIErrorItem : One of the errors reported in an error response.
+type APIErrorItem struct {
+	// An identifier of the error, such as `not_found` or `validation_invalid_argument`.
+	Code string `json:"code"`
+
+	// An explanation of the error.
+	Message string `json:"message"`
+
+	// A link to documentation about the error.
+	MoreInfo string `json:"more_info,omitempty"`
+
+	// The request property, parameter or header the error relates to.
+	Target *APIErrorTarget `json:"target,omitempty"`
+}
+
+// APIErrorTarget : The request property, parameter or header an error relates to.
+type APIErrorTarget struct {
+	// The name of the target.
+	Name string `json:"name"`
+
+	// The type of the target: `field`, `header` or `parameter`.
+	Type string `json:"type"`
+
+	// The value of the target, if any.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// apiErrorBody is the body of an error response.
+type apiErrorBody struct {
+	Errors []APIErrorItem `json:"errors"`
+	Trace  string         `json:"trace"`
+}
+
+// NewAPIError returns an *APIError describing the error returned by an operation along with
+// its detailed response. It returns err unchanged if no response was received (e.g. for a
+// network error), and nil if err is nil.
+func NewAPIError(response *core.DetailedResponse, err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) || response == nil || response.StatusCode < 300 {
+		return err
+	}
+
+	apiErr = &APIError{
+		StatusCode: response.StatusCode,
+		Response:   response,
+		Err:        err,
+	}
+	if response.Headers != nil {
+		apiErr.RequestID = response.Headers.Get(common.X_REQUEST_ID)
+	}
+
+	var buf []byte
+	if result, ok := response.GetResultAsMap(); ok {
+		buf, _ = json.Marshal(result)
+	} else {
+		buf = response.RawResult
+	}
+	var body apiErrorBody
+	if len(buf) > 0 && json.Unmarshal(buf, &body) == nil {
+		apiErr.Errors = body.Errors
+		apiErr.Trace = body.Trace
+	}
+	return apiErr
+}
+
+// Error returns the error message.
+func (apiErr *APIError) Error() string {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "%d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+	if len(apiErr.Errors) == 0 && apiErr.Err != nil {
+		fmt.Fprintf(&msg, ": %s", apiErr.Err.Error())
+	}
+	for i, item := range apiErr.Errors {
+		separator := "; "
+		if i == 0 {
+			separator = ": "
+		}
+		fmt.Fprintf(&msg, "%s%s (%s)", separator, item.Message, item.Code)
+	}
+	if apiErr.Trace != "" {
+		fmt.Fprintf(&msg, " [trace: %s]", apiErr.Trace)
+	}
+	return msg.String()
+}
+
+// Unwrap returns the error returned by the operation.
+func (apiErr *APIError) Unwrap() error {
+	return apiErr.Err
+}
+
+// HasCode returns true if one of the errors reported in the response has the given code.
+func (apiErr *APIError) HasCode(code string) bool {
+	for _, item := range apiErr.Errors {
+		if item.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCodeMatching returns true if one of the errors reported in the response has a code
+// for which match returns true.
+func (apiErr *APIError) hasCodeMatching(match func(code string) bool) bool {
+	for _, item := range apiErr.Errors {
+		if match(item.Code) {
+			return true
+		}
+	}
+	return false
+}
+
+// AsAPIError returns the *APIError in err's chain, if any.
+func AsAPIError(err error) (apiErr *APIError, ok bool) {
+	ok = errors.As(err, &apiErr)
+	return
+}
+
+// IsNotFound returns true if err is an *APIError for a 404 Not Found response.
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict returns true if err is an *APIError for a 409 Conflict response, as returned
+// for example when deleting a resource that is still in use.
+func IsConflict(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusConflict
+}
+
+// IsPreconditionFailed returns true if err is an *APIError for a 412 Precondition Failed
+// response, returned when the If-Match value does not match the resource's current ETag.
+func IsPreconditionFailed(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// IsQuotaExceeded returns true if err is an *APIError reporting that an account quota
+// or limit has been reached.
+func IsQuotaExceeded(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.hasCodeMatching(func(code string) bool {
+		return strings.Contains(code, "quota")
+	})
+}
+
+// IsValidationError returns true if err is an *APIError for a 400 Bad Request response
+// or reporting a `validation_...` error code.
+func IsValidationError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusBadRequest || apiErr.hasCodeMatching(func(code string) bool {
+		return strings.HasPrefix(code, "validation_")
+	}))
+}
+
+// IsRateLimited returns true if err is an *APIError for a 429 Too Many Requests response.
+func IsRateLimited(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusTooManyRequests
+}
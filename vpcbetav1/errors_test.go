@@ -0,0 +1,139 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/common"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// invoke sends a request the way the generated operations do, with optional pairs of
+// header names and values, and returns the results of BaseService.Request.
+func invoke(service *core.BaseService, method string, path string, body interface{}, headers ...string) (map[string]interface{}, *core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	_, err := builder.ResolveRequestURL(service.Options.URL, path, nil)
+	Expect(err).To(BeNil())
+	for headerName, headerValue := range common.GetSdkHeaders("vpc", "V1", "test_operation") {
+		builder.AddHeader(headerName, headerValue)
+	}
+	builder.AddHeader("Accept", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		builder.AddHeader(headers[i], headers[i+1])
+	}
+	builder.AddQuery("version", "2024-03-12")
+	if body != nil {
+		_, err = builder.SetBodyContentJSON(body)
+		Expect(err).To(BeNil())
+	}
+	request, err := builder.Build()
+	Expect(err).To(BeNil())
+
+	var result map[string]interface{}
+	response, err := service.Request(request, &result)
+	return result, response, err
+}
+
+var _ = Describe(`APIError`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Describes a not found error`, func() {
+		_, response, err := invoke(service, core.GET, "/vpcs/unknown", nil)
+		err = vpcbetav1.NewAPIError(response, err)
+		Expect(err).ToNot(BeNil())
+
+		var apiErr *vpcbetav1.APIError
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(apiErr.Errors).To(HaveLen(1))
+		Expect(apiErr.Errors[0].Code).To(Equal("vpc_not_found"))
+		Expect(apiErr.Errors[0].MoreInfo).ToNot(BeEmpty())
+		Expect(apiErr.Trace).ToNot(BeEmpty())
+		Expect(apiErr.RequestID).ToNot(BeEmpty())
+		Expect(apiErr.HasCode("vpc_not_found")).To(BeTrue())
+		Expect(apiErr.Error()).To(ContainSubstring("vpc_not_found"))
+
+		Expect(vpcbetav1.IsNotFound(err)).To(BeTrue())
+		Expect(vpcbetav1.IsNotFound(fmt.Errorf("wrapped: %w", err))).To(BeTrue())
+		Expect(vpcbetav1.IsConflict(err)).To(BeFalse())
+	})
+	It(`Describes validation, conflict and precondition errors`, func() {
+		_, response, err := invoke(service, core.POST, "/keys", map[string]interface{}{"name": "my-key"})
+		err = vpcbetav1.NewAPIError(response, err)
+		Expect(vpcbetav1.IsValidationError(err)).To(BeTrue())
+		apiErr, _ := vpcbetav1.AsAPIError(err)
+		Expect(apiErr.Errors[0].Code).To(Equal("missing_field"))
+
+		vpc, _, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		_, _, err = invoke(service, core.POST, "/subnets", map[string]interface{}{
+			"vpc": map[string]interface{}{"id": vpc["id"]}, "ipv4_cidr_block": "10.0.0.0/24",
+		})
+		Expect(err).To(BeNil())
+		_, response, err = invoke(service, core.DELETE, fmt.Sprintf("/vpcs/%s", vpc["id"]), nil)
+		Expect(vpcbetav1.IsConflict(vpcbetav1.NewAPIError(response, err))).To(BeTrue())
+
+		_, response, err = invoke(service, core.PATCH, fmt.Sprintf("/vpcs/%s", vpc["id"]), map[string]interface{}{}, "If-Match", `W/"stale"`)
+		Expect(vpcbetav1.IsPreconditionFailed(vpcbetav1.NewAPIError(response, err))).To(BeTrue())
+	})
+	It(`Describes quota and rate limit errors`, func() {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.Header().Set("Content-type", "application/json")
+			if req.Method == http.MethodPost {
+				res.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(res, `{"errors":[{"code":"over_quota","message":"Quota exceeded."}],"trace":"abc"}`)
+				return
+			}
+			res.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer testServer.Close()
+		service = newTestService(testServer.URL)
+
+		_, response, err := invoke(service, core.POST, "/instances", map[string]interface{}{})
+		err = vpcbetav1.NewAPIError(response, err)
+		Expect(vpcbetav1.IsQuotaExceeded(err)).To(BeTrue())
+		Expect(err.Error()).To(Equal("400 Bad Request: Quota exceeded. (over_quota) [trace: abc]"))
+
+		_, response, err = invoke(service, core.GET, "/instances", nil)
+		err = vpcbetav1.NewAPIError(response, err)
+		Expect(vpcbetav1.IsRateLimited(err)).To(BeTrue())
+		Expect(vpcbetav1.IsQuotaExceeded(err)).To(BeFalse())
+	})
+	It(`Leaves other errors unchanged`, func() {
+		Expect(vpcbetav1.NewAPIError(nil, nil)).To(BeNil())
+		original := errors.New("connection refused")
+		Expect(vpcbetav1.NewAPIError(nil, original)).To(Equal(original))
+		Expect(vpcbetav1.IsNotFound(original)).To(BeFalse())
+	})
+})
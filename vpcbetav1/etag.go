@@ -0,0 +1,142 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultConcurrentUpdateRetries is the number of times UpdateWithRetry and DeleteWithRetry
+// retry after a 412 Precondition Failed response, unless configured otherwise.
+const DefaultConcurrentUpdateRetries = 5
+
+// ConcurrentUpdateOptions : The ConcurrentUpdateOptions struct configures UpdateWithRetry
+// and DeleteWithRetry.
+type ConcurrentUpdateOptions struct {
+	// The number of times to retry after a 412 Precondition Failed response.
+	// If not set, DefaultConcurrentUpdateRetries is used; a negative value disables retries.
+	MaxRetries int
+
+	// The delay before the first retry, doubled (with jitter) on each subsequent retry.
+	MinDelay time.Duration
+
+	// The upper bound of the delay between two retries.
+	MaxDelay time.Duration
+}
+
+// GetETag returns the value of the ETag header of a response, or "" if there is none.
+// It is the value to pass to the SetIfMatch method of the Update... and Delete... options.
+func GetETag(response *core.DetailedResponse) string {
+	if response == nil || response.Headers == nil {
+		return ""
+	}
+	return response.Headers.Get("ETag")
+}
+
+// UpdateWithRetry performs an optimistic read-modify-write of a resource:
+// it retrieves the resource with get, computes a merge patch with mutate (e.g. the result of
+// the AsPatch method of a ...Patch model), and applies it with update using the ETag of the
+// retrieved resource as If-Match value.
+// If the resource changed in between (412 Precondition Failed), the whole cycle is retried.
+// If mutate returns a nil or empty patch, no update is made and the retrieved resource is returned.
+func UpdateWithRetry[T any](ctx context.Context,
+	get func(ctx context.Context) (T, *core.DetailedResponse, error),
+	mutate func(current T) (map[string]interface{}, error),
+	update func(ctx context.Context, patch map[string]interface{}, ifMatch string) (T, *core.DetailedResponse, error),
+	options *ConcurrentUpdateOptions) (result T, response *core.DetailedResponse, err error) {
+	if get == nil || mutate == nil || update == nil {
+		err = fmt.Errorf("get, mutate and update functions cannot be nil")
+		return
+	}
+
+	err = retryOnPreconditionFailed(ctx, options, func() error {
+		var current T
+		current, response, err = get(ctx)
+		if err != nil {
+			return NewAPIError(response, err)
+		}
+		patch, mutateErr := mutate(current)
+		if mutateErr != nil {
+			return mutateErr
+		}
+		if len(patch) == 0 {
+			result = current
+			return nil
+		}
+		result, response, err = update(ctx, patch, GetETag(response))
+		return NewAPIError(response, err)
+	})
+	return
+}
+
+// DeleteWithRetry deletes a resource with an If-Match value: it retrieves the resource with
+// get and deletes it with remove using the ETag of the retrieved resource.
+// If the resource changed in between (412 Precondition Failed), the whole cycle is retried.
+func DeleteWithRetry[T any](ctx context.Context,
+	get func(ctx context.Context) (T, *core.DetailedResponse, error),
+	remove func(ctx context.Context, ifMatch string) (*core.DetailedResponse, error),
+	options *ConcurrentUpdateOptions) (response *core.DetailedResponse, err error) {
+	if get == nil || remove == nil {
+		err = fmt.Errorf("get and remove functions cannot be nil")
+		return
+	}
+
+	err = retryOnPreconditionFailed(ctx, options, func() error {
+		_, response, err = get(ctx)
+		if err != nil {
+			return NewAPIError(response, err)
+		}
+		response, err = remove(ctx, GetETag(response))
+		return NewAPIError(response, err)
+	})
+	return
+}
+
+// retryOnPreconditionFailed invokes attempt until it returns an error other than
+// 412 Precondition Failed or the retries are exhausted.
+func retryOnPreconditionFailed(ctx context.Context, options *ConcurrentUpdateOptions, attempt func() error) (err error) {
+	maxRetries := DefaultConcurrentUpdateRetries
+	backoff := &WaiterOptions{MinDelay: 100 * time.Millisecond, MaxDelay: 5 * time.Second}
+	if options != nil {
+		if options.MaxRetries < 0 {
+			maxRetries = 0
+		} else if options.MaxRetries > 0 {
+			maxRetries = options.MaxRetries
+		}
+		if options.MinDelay > 0 {
+			backoff.MinDelay = options.MinDelay
+		}
+		if options.MaxDelay > 0 {
+			backoff.MaxDelay = options.MaxDelay
+		}
+	}
+
+	for retry := 1; ; retry++ {
+		err = attempt()
+		if !IsPreconditionFailed(err) || retry > maxRetries {
+			return
+		}
+
+		if sleepErr := sleepContext(ctx, backoffDelay(backoff, retry)); sleepErr != nil {
+			return sleepErr
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Concurrent updates`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var volumePath string
+
+	getVolume := func(ctx context.Context) (map[string]interface{}, *core.DetailedResponse, error) {
+		return invoke(service, core.GET, volumePath, nil)
+	}
+	updateVolume := func(ctx context.Context, patch map[string]interface{}, ifMatch string) (map[string]interface{}, *core.DetailedResponse, error) {
+		return invoke(service, core.PATCH, volumePath, patch, "If-Match", ifMatch)
+	}
+	options := &vpcbetav1.ConcurrentUpdateOptions{MinDelay: time.Millisecond}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		volume, _, err := invoke(service, core.POST, "/volumes", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "general-purpose"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+		})
+		Expect(err).To(BeNil())
+		volumePath = fmt.Sprintf("/volumes/%s", volume["id"])
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Invoke UpdateWithRetry successfully`, func() {
+		attempts := 0
+		volume, response, err := vpcbetav1.UpdateWithRetry(context.Background(), getVolume,
+			func(current map[string]interface{}) (map[string]interface{}, error) {
+				attempts++
+				if attempts == 1 {
+					// Simulate a concurrent update between the read and the write.
+					_, _, err := invoke(service, core.PATCH, volumePath, map[string]interface{}{"name": "renamed"})
+					Expect(err).To(BeNil())
+				}
+				return map[string]interface{}{"capacity": current["capacity"].(float64) + 10}, nil
+			}, updateVolume, options)
+		Expect(err).To(BeNil())
+		Expect(attempts).To(Equal(2))
+		Expect(volume["capacity"]).To(BeNumerically("==", 110))
+		Expect(volume["name"]).To(Equal("renamed"))
+		Expect(vpcbetav1.GetETag(response)).ToNot(BeEmpty())
+	})
+	It(`Skips the update when there is nothing to change`, func() {
+		volume, _, err := vpcbetav1.UpdateWithRetry(context.Background(), getVolume,
+			func(current map[string]interface{}) (map[string]interface{}, error) {
+				return nil, nil
+			}, func(ctx context.Context, patch map[string]interface{}, ifMatch string) (map[string]interface{}, *core.DetailedResponse, error) {
+				Fail("update should not be called")
+				return nil, nil, nil
+			}, options)
+		Expect(err).To(BeNil())
+		Expect(volume["capacity"]).To(BeNumerically("==", 100))
+	})
+	It(`Gives up after the configured number of retries`, func() {
+		attempts := 0
+		_, _, err := vpcbetav1.UpdateWithRetry(context.Background(), getVolume,
+			func(current map[string]interface{}) (map[string]interface{}, error) {
+				attempts++
+				return map[string]interface{}{"capacity": 200}, nil
+			}, func(ctx context.Context, patch map[string]interface{}, ifMatch string) (map[string]interface{}, *core.DetailedResponse, error) {
+				return updateVolume(ctx, patch, `W/"stale"`)
+			}, &vpcbetav1.ConcurrentUpdateOptions{MaxRetries: 2, MinDelay: time.Millisecond})
+		Expect(vpcbetav1.IsPreconditionFailed(err)).To(BeTrue())
+		Expect(attempts).To(Equal(3))
+	})
+	It(`Invoke UpdateWithRetry with error`, func() {
+		mutateErr := errors.New("invalid capacity")
+		_, _, err := vpcbetav1.UpdateWithRetry(context.Background(), getVolume,
+			func(current map[string]interface{}) (map[string]interface{}, error) {
+				return nil, mutateErr
+			}, updateVolume, options)
+		Expect(err).To(Equal(mutateErr))
+
+		volumePath = "/volumes/unknown"
+		_, _, err = vpcbetav1.UpdateWithRetry(context.Background(), getVolume,
+			func(current map[string]interface{}) (map[string]interface{}, error) {
+				return map[string]interface{}{"capacity": 200}, nil
+			}, updateVolume, options)
+		Expect(vpcbetav1.IsNotFound(err)).To(BeTrue())
+
+		_, _, err = vpcbetav1.UpdateWithRetry(context.Background(), getVolume, nil, updateVolume, options)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke DeleteWithRetry successfully`, func() {
+		response, err := vpcbetav1.DeleteWithRetry(context.Background(), getVolume,
+			func(ctx context.Context, ifMatch string) (*core.DetailedResponse, error) {
+				Expect(ifMatch).ToNot(BeEmpty())
+				_, response, err := invoke(service, core.DELETE, volumePath, nil, "If-Match", ifMatch)
+				return response, err
+			}, options)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(204))
+
+		_, _, err = getVolume(context.Background())
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,290 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/IBM/vpc-beta-go-sdk/common"
+)
+
+// FaultKind : The kind of a fault injected by a FaultTransport.
+type FaultKind int
+
+const (
+	// FaultDelay only delays the request by the latency of the fault.
+	FaultDelay FaultKind = iota
+
+	// FaultStatus answers the request with an error response, without sending it.
+	FaultStatus
+
+	// FaultConnectionReset fails the request with a connection reset error, without sending it.
+	FaultConnectionReset
+
+	// FaultTruncatedBody sends the request and truncates the body of the response, so that
+	// it is no longer valid JSON.
+	FaultTruncatedBody
+)
+
+// Fault : A fault injected by a FaultTransport.
+type Fault struct {
+	// The kind of the fault.
+	Kind FaultKind
+
+	// The status code of the response, for FaultStatus.
+	StatusCode int
+
+	// The value of the Retry-After header of the response, for FaultStatus. Ignored if zero.
+	RetryAfter time.Duration
+
+	// The delay before the fault is applied (or the request is sent). A slow response is
+	// simulated with a FaultDelay with a latency.
+	Latency time.Duration
+}
+
+// NewStatusFault returns a fault answering requests with the given status code.
+func NewStatusFault(statusCode int) *Fault {
+	return &Fault{Kind: FaultStatus, StatusCode: statusCode}
+}
+
+// NewDelayFault returns a fault delaying requests by the given latency.
+func NewDelayFault(latency time.Duration) *Fault {
+	return &Fault{Kind: FaultDelay, Latency: latency}
+}
+
+// FaultRule : A rule of a FaultTransport, selecting the requests it applies to and the
+// faults to inject into them.
+type FaultRule struct {
+	// The operation IDs (see OperationID) of the requests the rule applies to, such as
+	// `create_instance`. If empty, the rule applies to all requests.
+	Operations []string
+
+	// A scripted schedule: the n-th request the rule applies to gets the n-th fault, where a
+	// nil fault lets the request through. Once the schedule is exhausted, requests are let
+	// through. If set, Fault and Probability are ignored.
+	Schedule []*Fault
+
+	// The fault injected with the given probability, if there is no schedule.
+	Fault *Fault
+
+	// The probability, between 0 and 1, of injecting the fault. If zero, the fault is
+	// always injected.
+	Probability float64
+
+	count int
+}
+
+// next returns the fault to inject into the next request the rule applies to, or nil.
+func (rule *FaultRule) next(random func() float64) *Fault {
+	defer func() { rule.count++ }()
+	if len(rule.Schedule) > 0 {
+		if rule.count < len(rule.Schedule) {
+			return rule.Schedule[rule.count]
+		}
+		return nil
+	}
+	if rule.Probability > 0 && random() >= rule.Probability {
+		return nil
+	}
+	return rule.Fault
+}
+
+// matches returns true if the rule applies to the operation.
+func (rule *FaultRule) matches(operationID string) bool {
+	if len(rule.Operations) == 0 {
+		return true
+	}
+	for _, operation := range rule.Operations {
+		if operation == operationID {
+			return true
+		}
+	}
+	return false
+}
+
+// FaultTransport : An http.RoundTripper that injects faults into requests: error responses,
+// connection resets, slow responses and truncated JSON bodies. To use it, wrap the transport
+// of the service's HTTP client:
+//
+//	transport := vpcbetav1.NewFaultTransport(nil, &vpcbetav1.FaultRule{
+//		Operations: []string{"create_instance"},
+//		Schedule:   []*vpcbetav1.Fault{vpcbetav1.NewStatusFault(503), nil},
+//	})
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: transport})
+//
+// For each request, the rules matching its operation are evaluated in order, and the first
+// fault they yield is injected.
+type FaultTransport struct {
+	// The base transport. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// The rules.
+	Rules []*FaultRule
+
+	// The source of random numbers in [0, 1) for probabilistic rules. If nil, math/rand is used.
+	Random func() float64
+
+	mutex    sync.Mutex
+	injected map[string]int
+}
+
+// NewFaultTransport returns a new FaultTransport wrapping the given transport.
+func NewFaultTransport(transport http.RoundTripper, rules ...*FaultRule) *FaultTransport {
+	return &FaultTransport{
+		Transport: transport,
+		Rules:     rules,
+	}
+}
+
+// Injected returns the number of faults injected into requests of the given operation.
+func (transport *FaultTransport) Injected(operationID string) int {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	return transport.injected[operationID]
+}
+
+// RoundTrip injects the fault selected by the rules, if any, and sends the request.
+func (transport *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := transport.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	operationID := operationIDOf(req)
+	fault := transport.selectFault(operationID)
+	if fault == nil {
+		return base.RoundTrip(req)
+	}
+
+	if fault.Latency > 0 {
+		if err := sleepContext(req.Context(), fault.Latency); err != nil {
+			closeRequestBody(req)
+			return nil, err
+		}
+	}
+
+	switch fault.Kind {
+	case FaultStatus:
+		closeRequestBody(req)
+		return faultResponse(req, fault), nil
+	case FaultConnectionReset:
+		closeRequestBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	case FaultTruncatedBody:
+		res, err := base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = body[:len(body)/2]
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		res.ContentLength = int64(len(body))
+		res.Header.Del("Content-Length")
+		return res, nil
+	}
+	return base.RoundTrip(req)
+}
+
+// selectFault evaluates the rules for a request of the given operation.
+func (transport *FaultTransport) selectFault(operationID string) *Fault {
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+	random := transport.Random
+	if random == nil {
+		random = rand.Float64 // #nosec G404
+	}
+	for _, rule := range transport.Rules {
+		if !rule.matches(operationID) {
+			continue
+		}
+		if fault := rule.next(random); fault != nil {
+			if transport.injected == nil {
+				transport.injected = make(map[string]int)
+			}
+			transport.injected[operationID]++
+			return fault
+		}
+	}
+	return nil
+}
+
+// faultErrorCodes maps the status codes of injected responses to VPC error codes.
+var faultErrorCodes = map[int]string{
+	http.StatusTooManyRequests:     "too_many_requests",
+	http.StatusInternalServerError: "internal_error",
+	http.StatusBadGateway:          "bad_gateway",
+	http.StatusServiceUnavailable:  "service_unavailable",
+	http.StatusGatewayTimeout:      "gateway_timeout",
+}
+
+// faultResponse returns an error response in the format of the VPC API.
+func faultResponse(req *http.Request, fault *Fault) *http.Response {
+	code := faultErrorCodes[fault.StatusCode]
+	if code == "" {
+		code = "injected_fault"
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"code":      code,
+			"message":   fmt.Sprintf("Fault injected into %s.", operationIDOf(req)),
+			"more_info": "https://cloud.ibm.com/docs/vpc?topic=vpc-rias-error-messages",
+		}},
+		"status_code": fault.StatusCode,
+		"trace":       "fault-injection",
+	})
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	if requestID := req.Header.Get(common.X_REQUEST_ID); requestID != "" {
+		header.Set(common.X_REQUEST_ID, requestID)
+	}
+	if fault.RetryAfter > 0 {
+		header.Set("Retry-After", strconv.Itoa(int((fault.RetryAfter+time.Second-1)/time.Second)))
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fault.StatusCode, http.StatusText(fault.StatusCode)),
+		StatusCode:    fault.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// closeRequestBody closes the body of a request that is not sent.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
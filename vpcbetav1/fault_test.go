@@ -0,0 +1,163 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`OperationID`, func() {
+	It(`Derives operation IDs from requests`, func() {
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/instances")).To(Equal("list_instances"))
+		Expect(vpcbetav1.OperationID(http.MethodPost, "/v1/instances")).To(Equal("create_instance"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/instances/r006-1")).To(Equal("get_instance"))
+		Expect(vpcbetav1.OperationID(http.MethodPatch, "/v1/instances/r006-1")).To(Equal("update_instance"))
+		Expect(vpcbetav1.OperationID(http.MethodDelete, "/v1/instances/r006-1")).To(Equal("delete_instance"))
+		Expect(vpcbetav1.OperationID(http.MethodPost, "/v1/instances/r006-1/actions")).To(Equal("create_instance_action"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/vpcs/r006-1/address_prefixes")).To(Equal("list_vpc_address_prefixes"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/vpcs/r006-1/address_prefixes/r006-2")).To(Equal("get_vpc_address_prefix"))
+		Expect(vpcbetav1.OperationID(http.MethodPut, "/v1/subnets/r006-1/network_acl")).To(Equal("replace_subnet_network_acl"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/network_acls/r006-1/rules")).To(Equal("list_network_acl_rules"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/floating_ips")).To(Equal("list_floating_ips"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "/v1/load_balancers/r006-1/listeners/r006-2/policies")).To(Equal("list_load_balancer_listener_policies"))
+		Expect(vpcbetav1.OperationID(http.MethodPost, "/v1/load_balancers/r006-1/listeners/r006-2/policies")).To(Equal("create_load_balancer_listener_policy"))
+		Expect(vpcbetav1.OperationID(http.MethodGet, "https://proxy/vpc/v1/vpcs")).To(Equal("list_vpcs"))
+	})
+	It(`Derives operation IDs of collections with a singular prefix`, func() {
+		for path, operationID := range map[string]string{
+			"/v1/instance/profiles":                            "list_instance_profiles",
+			"/v1/instance/profiles/bx2-2x8":                    "get_instance_profile",
+			"/v1/volume/profiles":                              "list_volume_profiles",
+			"/v1/volume/profiles/10iops-tier":                  "get_volume_profile",
+			"/v1/bare_metal_server/profiles/bx2d-metal-96x384": "get_bare_metal_server_profile",
+			"/v1/dedicated_host/profiles":                      "list_dedicated_host_profiles",
+			"/v1/load_balancer/profiles/network-fixed":         "get_load_balancer_profile",
+			"/v1/share/profiles":                               "list_share_profiles",
+			"/v1/instance/templates/r006-1":                    "get_instance_template",
+			"/v1/dedicated_host/groups":                        "list_dedicated_host_groups",
+			"/v1/instances/r006-1/network_interfaces/r006-2":   "get_instance_network_interface",
+			"/v1/instances/r006-1/volume_attachments":          "list_instance_volume_attachments",
+		} {
+			Expect(vpcbetav1.OperationID(http.MethodGet, path)).To(Equal(operationID), path)
+		}
+		Expect(vpcbetav1.OperationID(http.MethodPost, "/v1/instance/templates")).To(Equal("create_instance_template"))
+		Expect(vpcbetav1.OperationID(http.MethodDelete, "/v1/dedicated_host/groups/r006-1")).To(Equal("delete_dedicated_host_group"))
+	})
+})
+
+var _ = Describe(`FaultTransport`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var transport *vpcbetav1.FaultTransport
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		transport = vpcbetav1.NewFaultTransport(nil)
+		service.SetHTTPClient(&http.Client{Transport: transport})
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Injects scripted faults into matching operations`, func() {
+		transport.Rules = []*vpcbetav1.FaultRule{{
+			Operations: []string{"create_vpc"},
+			Schedule: []*vpcbetav1.Fault{
+				{Kind: vpcbetav1.FaultStatus, StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second},
+				vpcbetav1.NewStatusFault(http.StatusServiceUnavailable),
+				nil,
+			},
+		}}
+
+		_, response, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"}, "X-Request-Id", "req-1")
+		Expect(vpcbetav1.IsRateLimited(vpcbetav1.NewAPIError(response, err))).To(BeTrue())
+		Expect(response.Headers.Get("Retry-After")).To(Equal("2"))
+		Expect(response.Headers.Get("X-Request-Id")).To(Equal("req-1"))
+
+		_, response, err = invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(vpcbetav1.NewAPIError(response, err).(*vpcbetav1.APIError).HasCode("service_unavailable")).To(BeTrue())
+
+		// Other operations are not affected, and the schedule is exhausted.
+		_, _, err = invoke(service, core.GET, "/vpcs", nil)
+		Expect(err).To(BeNil())
+		for i := 0; i < 2; i++ {
+			_, response, err = invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+			Expect(err).To(BeNil())
+			Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		}
+		Expect(transport.Injected("create_vpc")).To(Equal(2))
+		Expect(transport.Injected("list_vpcs")).To(Equal(0))
+	})
+	It(`Injects probabilistic faults`, func() {
+		values := []float64{0.1, 0.9, 0.4, 0.6}
+		transport.Random = func() float64 {
+			value := values[0]
+			values = values[1:]
+			return value
+		}
+		transport.Rules = []*vpcbetav1.FaultRule{{
+			Fault:       vpcbetav1.NewStatusFault(http.StatusInternalServerError),
+			Probability: 0.5,
+		}}
+		var statuses []int
+		for i := 0; i < 4; i++ {
+			_, response, _ := invoke(service, core.GET, "/vpcs", nil)
+			statuses = append(statuses, response.StatusCode)
+		}
+		Expect(statuses).To(Equal([]int{500, 200, 500, 200}))
+	})
+	It(`Injects connection resets and truncated bodies`, func() {
+		transport.Rules = []*vpcbetav1.FaultRule{{
+			Operations: []string{"list_vpcs"},
+			Schedule:   []*vpcbetav1.Fault{{Kind: vpcbetav1.FaultConnectionReset}, {Kind: vpcbetav1.FaultTruncatedBody}},
+		}}
+		_, _, err := invoke(service, core.GET, "/vpcs", nil)
+		Expect(errors.Is(err, syscall.ECONNRESET)).To(BeTrue())
+
+		_, response, err := invoke(service, core.GET, "/vpcs", nil)
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+	It(`Injects latency`, func() {
+		transport.Rules = []*vpcbetav1.FaultRule{{Fault: vpcbetav1.NewDelayFault(50 * time.Millisecond)}}
+		start := time.Now()
+		_, _, err := invoke(service, core.GET, "/vpcs", nil)
+		Expect(err).To(BeNil())
+		Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+
+		transport.Rules[0].Fault.Latency = time.Minute
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.ServiceURL()+"/vpcs?version=2024-03-12", nil)
+		Expect(err).To(BeNil())
+		_, err = transport.RoundTrip(request)
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+	})
+})
@@ -0,0 +1,195 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// IdempotencyTagPrefix is the prefix of the user tags holding idempotency tokens.
+const IdempotencyTagPrefix = "idempotency-token:"
+
+// DefaultIdempotentCreateRetries is the number of times CreateIdempotent retries a create
+// operation after an ambiguous failure, unless configured otherwise.
+const DefaultIdempotentCreateRetries = 3
+
+// maxNameLength is the maximum length of resource names.
+const maxNameLength = 63
+
+// IdempotencyToken returns a deterministic token derived from the given keys, such as the
+// name of a workflow and of the resource it creates. The same keys always give the same token.
+func IdempotencyToken(keys ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(keys, "\x00")))
+	return hex.EncodeToString(hash[:16])
+}
+
+// IdempotencyTag returns the user tag holding the given token, to add to the `user_tags` of
+// a create request.
+func IdempotencyTag(token string) string {
+	return IdempotencyTagPrefix + token
+}
+
+// IdempotentName returns a resource name made of the given base name and a suffix derived
+// from the token, truncated to the maximum length of names.
+func IdempotentName(base string, token string) string {
+	suffix := token
+	if len(suffix) > 12 {
+		suffix = suffix[:12]
+	}
+	if max := maxNameLength - len(suffix) - 1; len(base) > max {
+		base = base[:max]
+	}
+	base = strings.TrimRight(base, "-")
+	if base == "" {
+		return "r-" + suffix
+	}
+	return base + "-" + suffix
+}
+
+// HasIdempotencyTag returns true if the user tags include the tag holding the given token.
+func HasIdempotencyTag(userTags []string, token string) bool {
+	tag := IdempotencyTag(token)
+	for _, userTag := range userTags {
+		if userTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// FindItem returns the first item of a sequence (see Pager.Items and CollectionItems) that
+// matches, and whether one was found.
+func FindItem[T any](items Seq2[T, error], match func(T) bool) (item T, found bool, err error) {
+	items(func(candidate T, itemErr error) bool {
+		if itemErr != nil {
+			err = itemErr
+			return false
+		}
+		if match(candidate) {
+			item, found = candidate, true
+			return false
+		}
+		return true
+	})
+	return
+}
+
+// IdempotentCreateOptions : The IdempotentCreateOptions struct configures CreateIdempotent.
+type IdempotentCreateOptions struct {
+	// The number of times the create operation is retried after an ambiguous failure. If not
+	// set, DefaultIdempotentCreateRetries is used; a negative value disables retries.
+	MaxRetries int
+
+	// The minimum and maximum delays between retries. If not set, 1 second and 30 seconds
+	// are used.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// If true, the resource is looked up before the first create as well, so that a create
+	// interrupted in an earlier run of the program is not repeated.
+	LookupFirst bool
+}
+
+// CreateIdempotent makes a create operation safe to retry. The create function must tag the
+// resource with a deterministic token (see IdempotencyToken, IdempotencyTag and
+// IdempotentName), and the lookup function must find a resource with that token:
+//
+//	name := vpcbetav1.IdempotentName("web-1", vpcbetav1.IdempotencyToken("my-workflow", "web-1"))
+//	instance, _, err := vpcbetav1.CreateIdempotent(ctx,
+//		func(ctx context.Context) (*vpcbetav1.Instance, *core.DetailedResponse, error) {
+//			instancePrototype.Name = &name
+//			return vpcService.CreateInstanceWithContext(ctx, createInstanceOptions)
+//		},
+//		func(ctx context.Context) (*vpcbetav1.Instance, bool, error) {
+//			instances, _, err := vpcService.ListInstancesWithContext(ctx, &vpcbetav1.ListInstancesOptions{Name: &name})
+//			if err != nil || len(instances.Instances) == 0 {
+//				return nil, false, err
+//			}
+//			return &instances.Instances[0], true, nil
+//		}, nil)
+//
+// The create is retried after an ambiguous failure (a transport error, or a 408, 429 or 5xx
+// response, after which the resource may or may not have been created); before each retry,
+// the resource is looked up and returned if found, with a nil response (as are the errors of
+// the lookup). Other failures are returned right away.
+func CreateIdempotent[T any](ctx context.Context,
+	create func(ctx context.Context) (T, *core.DetailedResponse, error),
+	lookup func(ctx context.Context) (T, bool, error),
+	options *IdempotentCreateOptions) (result T, response *core.DetailedResponse, err error) {
+	if create == nil || lookup == nil {
+		err = fmt.Errorf("create and lookup functions cannot be nil")
+		return
+	}
+	if options == nil {
+		options = &IdempotentCreateOptions{}
+	}
+	maxRetries := DefaultIdempotentCreateRetries
+	if options.MaxRetries < 0 {
+		maxRetries = 0
+	} else if options.MaxRetries > 0 {
+		maxRetries = options.MaxRetries
+	}
+	backoff := &WaiterOptions{MinDelay: time.Second, MaxDelay: 30 * time.Second}
+	if options.MinDelay > 0 {
+		backoff.MinDelay = options.MinDelay
+	}
+	if options.MaxDelay > 0 {
+		backoff.MaxDelay = options.MaxDelay
+	}
+
+	lookupFirst := options.LookupFirst
+	for retry := 1; ; retry++ {
+		if lookupFirst {
+			var found bool
+			if result, found, err = lookup(ctx); err != nil || found {
+				// The response, if any, is the one of a failed create.
+				response = nil
+				return
+			}
+		}
+
+		result, response, err = create(ctx)
+		if err == nil || !isAmbiguousFailure(ctx, response) || retry > maxRetries {
+			return
+		}
+		if err = sleepContext(ctx, backoffDelay(backoff, retry)); err != nil {
+			return
+		}
+		lookupFirst = true
+	}
+}
+
+// isAmbiguousFailure returns true if a failed request may or may not have been processed.
+func isAmbiguousFailure(ctx context.Context, response *core.DetailedResponse) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if response == nil || response.StatusCode == 0 {
+		return true
+	}
+	return response.StatusCode == http.StatusRequestTimeout ||
+		response.StatusCode == http.StatusTooManyRequests ||
+		response.StatusCode >= http.StatusInternalServerError
+}
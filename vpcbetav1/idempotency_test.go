@@ -0,0 +1,180 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// lostResponseTransport sends requests but loses the responses of the first POST requests,
+// simulating a timeout after the server processed the request.
+type lostResponseTransport struct {
+	lost int
+}
+
+func (transport *lostResponseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil && req.Method == http.MethodPost && transport.lost > 0 {
+		transport.lost--
+		_ = res.Body.Close()
+		return nil, errors.New("read: connection timed out")
+	}
+	return res, err
+}
+
+var _ = Describe(`CreateIdempotent`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var creates int
+
+	token := vpcbetav1.IdempotencyToken("my-workflow", "my-floating-ip")
+	name := vpcbetav1.IdempotentName("my-floating-ip", token)
+	options := &vpcbetav1.IdempotentCreateOptions{MinDelay: time.Millisecond}
+
+	create := func(ctx context.Context) (map[string]interface{}, *core.DetailedResponse, error) {
+		creates++
+		return invoke(service, core.POST, "/floating_ips", map[string]interface{}{
+			"name":      name,
+			"user_tags": []string{vpcbetav1.IdempotencyTag(token)},
+			"zone":      map[string]interface{}{"name": "us-south-1"},
+		})
+	}
+	lookup := func(ctx context.Context) (map[string]interface{}, bool, error) {
+		pager, err := vpcbetav1.NewPager(func(ctx context.Context, start *string) ([]map[string]interface{}, *string, error) {
+			result, _, err := invoke(service, core.GET, "/floating_ips", nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			var items []map[string]interface{}
+			for _, item := range result["floating_ips"].([]interface{}) {
+				items = append(items, item.(map[string]interface{}))
+			}
+			return items, nil, nil
+		})
+		Expect(err).To(BeNil())
+		return vpcbetav1.FindItem(pager.Items(ctx), func(item map[string]interface{}) bool {
+			var userTags []string
+			for _, tag := range item["user_tags"].([]interface{}) {
+				userTags = append(userTags, tag.(string))
+			}
+			return vpcbetav1.HasIdempotencyTag(userTags, token)
+		})
+	}
+	countFloatingIPs := func() int {
+		result, _, err := invoke(service, core.GET, "/floating_ips", nil)
+		Expect(err).To(BeNil())
+		return len(result["floating_ips"].([]interface{}))
+	}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		creates = 0
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Returns the resource created by a request whose response was lost`, func() {
+		service.SetHTTPClient(&http.Client{Transport: &lostResponseTransport{lost: 1}})
+		result, response, err := vpcbetav1.CreateIdempotent(context.Background(), create, lookup, options)
+		Expect(err).To(BeNil())
+		Expect(response).To(BeNil())
+		Expect(result["name"]).To(Equal(name))
+		Expect(creates).To(Equal(1))
+		Expect(countFloatingIPs()).To(Equal(1))
+	})
+	It(`Returns the resource created by a request that failed`, func() {
+		failingCreate := func(ctx context.Context) (map[string]interface{}, *core.DetailedResponse, error) {
+			result, _, err := create(ctx)
+			Expect(err).To(BeNil())
+			Expect(result["name"]).To(Equal(name))
+			return nil, &core.DetailedResponse{StatusCode: http.StatusServiceUnavailable}, errors.New("Service Unavailable")
+		}
+		result, response, err := vpcbetav1.CreateIdempotent(context.Background(), failingCreate, lookup, options)
+		Expect(err).To(BeNil())
+		Expect(response).To(BeNil())
+		Expect(result["name"]).To(Equal(name))
+		Expect(creates).To(Equal(1))
+		Expect(countFloatingIPs()).To(Equal(1))
+	})
+	It(`Retries when the request was not processed`, func() {
+		service.SetHTTPClient(&http.Client{Transport: vpcbetav1.NewFaultTransport(nil, &vpcbetav1.FaultRule{
+			Schedule: []*vpcbetav1.Fault{vpcbetav1.NewStatusFault(http.StatusServiceUnavailable)},
+		})})
+		result, response, err := vpcbetav1.CreateIdempotent(context.Background(), create, lookup, options)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		Expect(result["name"]).To(Equal(name))
+		Expect(creates).To(Equal(2))
+		Expect(countFloatingIPs()).To(Equal(1))
+
+		// Looking up first finds the existing resource.
+		_, response, err = vpcbetav1.CreateIdempotent(context.Background(), create, lookup,
+			&vpcbetav1.IdempotentCreateOptions{LookupFirst: true})
+		Expect(err).To(BeNil())
+		Expect(response).To(BeNil())
+		Expect(creates).To(Equal(2))
+	})
+	It(`Does not retry other failures`, func() {
+		service.SetHTTPClient(&http.Client{Transport: vpcbetav1.NewFaultTransport(nil, &vpcbetav1.FaultRule{
+			Fault: vpcbetav1.NewStatusFault(http.StatusBadRequest),
+		})})
+		_, response, err := vpcbetav1.CreateIdempotent(context.Background(), create, lookup, options)
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(creates).To(Equal(1))
+
+		service.SetHTTPClient(&http.Client{Transport: vpcbetav1.NewFaultTransport(nil, &vpcbetav1.FaultRule{
+			Operations: []string{"create_floating_ip"},
+			Fault:      vpcbetav1.NewStatusFault(http.StatusInternalServerError),
+		})})
+		creates = 0
+		_, _, err = vpcbetav1.CreateIdempotent(context.Background(), create, lookup,
+			&vpcbetav1.IdempotentCreateOptions{MaxRetries: 2, MinDelay: time.Millisecond})
+		Expect(err).ToNot(BeNil())
+		Expect(creates).To(Equal(3))
+	})
+	It(`Requires create and lookup functions`, func() {
+		_, _, err := vpcbetav1.CreateIdempotent(context.Background(), create, nil, options)
+		Expect(err).ToNot(BeNil())
+		_, _, err = vpcbetav1.CreateIdempotent(context.Background(), nil, lookup, options)
+		Expect(err).ToNot(BeNil())
+		Expect(creates).To(Equal(0))
+	})
+	It(`Derives names and tokens deterministically`, func() {
+		Expect(vpcbetav1.IdempotencyToken("a", "b")).To(Equal(vpcbetav1.IdempotencyToken("a", "b")))
+		Expect(vpcbetav1.IdempotencyToken("a", "b")).ToNot(Equal(vpcbetav1.IdempotencyToken("ab")))
+		Expect(vpcbetav1.IdempotencyTag(token)).To(HavePrefix(vpcbetav1.IdempotencyTagPrefix))
+		Expect(name).To(Equal("my-floating-ip-" + token[:12]))
+
+		long := vpcbetav1.IdempotentName(strings.Repeat("a", 49)+"-"+strings.Repeat("b", 20), token)
+		Expect(long).To(HaveLen(62))
+		Expect(long).To(Equal(strings.Repeat("a", 49) + "-" + token[:12]))
+		Expect(vpcbetav1.IdempotentName("", token)).To(Equal("r-" + token[:12]))
+	})
+})
@@ -0,0 +1,80 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+)
+
+// Seq2 is an iterator over pairs of values.
+// It has the same underlying type as iter.Seq2, so with Go 1.23 or later it can be converted
+// to iter.Seq2 or used directly in a range-over-func loop:
+//
+//	for instance, err := range iterator {
+//		...
+//	}
+//
+// The SDK itself still supports Go 1.18, which is why the iter package is not used here.
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// Items returns an iterator over the remaining results of the pager.
+// Pages are fetched lazily with the given context, one at a time, as the consumer advances.
+// If a page cannot be retrieved, the error is yielded once (with the zero value of T) and the
+// iteration ends. Breaking out of the loop stops the iteration without fetching further pages.
+func (pager *Pager[T]) Items(ctx context.Context) Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for pager.HasNext() {
+			page, err := pager.GetNextWithContext(ctx)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CollectionItems returns an iterator over all resources of a paginated List operation whose
+// result is one of the ...Collection models. See NewCollectionPager and Pager.Items.
+func CollectionItems[T any](ctx context.Context, list CollectionListFunc, options *PagerOptions) Seq2[T, error] {
+	pager, err := NewCollectionPager[T](list, options)
+	if err != nil {
+		return func(yield func(T, error) bool) {
+			var zero T
+			yield(zero, err)
+		}
+	}
+	return pager.Items(ctx)
+}
+
+// CollectionObjects returns an iterator over all resources of a paginated List operation, as
+// the JSON objects of the given property of the collection. See NewCollectionObjectPager and
+// Pager.Items.
+func CollectionObjects(ctx context.Context, list CollectionListFunc, property string, options *PagerOptions) Seq2[map[string]interface{}, error] {
+	pager, err := NewCollectionObjectPager(list, property, options)
+	if err != nil {
+		return func(yield func(map[string]interface{}, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return pager.Items(ctx)
+}
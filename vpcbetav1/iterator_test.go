@@ -0,0 +1,90 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Iterator`, func() {
+	// pagedList serves three pages of two items each and counts the requests made.
+	pagedList := func(requests *int) vpcbetav1.CollectionListFunc {
+		return func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			*requests++
+			page := 0
+			if start != nil {
+				fmt.Sscanf(*start, "%d", &page)
+			}
+			collection := &testCollection{Items: []string{fmt.Sprintf("%d-a", page), fmt.Sprintf("%d-b", page)}}
+			if page < 2 {
+				collection.Next = &struct {
+					Href *string `json:"href"`
+				}{Href: core.StringPtr(fmt.Sprintf("https://myhost.com/somePath?start=%d", page+1))}
+			}
+			return collection, nil, nil
+		}
+	}
+
+	It(`Iterates over every item`, func() {
+		requests := 0
+		var items []string
+		vpcbetav1.CollectionItems[string](context.Background(), pagedList(&requests), nil)(func(item string, err error) bool {
+			Expect(err).To(BeNil())
+			items = append(items, item)
+			return true
+		})
+		Expect(items).To(Equal([]string{"0-a", "0-b", "1-a", "1-b", "2-a", "2-b"}))
+		Expect(requests).To(Equal(3))
+	})
+	It(`Stops fetching when the consumer breaks`, func() {
+		requests := 0
+		var items []string
+		vpcbetav1.CollectionItems[string](context.Background(), pagedList(&requests), nil)(func(item string, err error) bool {
+			items = append(items, item)
+			return len(items) < 3
+		})
+		Expect(items).To(HaveLen(3))
+		Expect(requests).To(Equal(2))
+	})
+	It(`Yields the error and stops`, func() {
+		failure := errors.New("list failed")
+		calls := 0
+		vpcbetav1.CollectionItems[string](context.Background(), func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			return nil, nil, failure
+		}, nil)(func(item string, err error) bool {
+			calls++
+			Expect(err).To(Equal(failure))
+			return true
+		})
+		Expect(calls).To(Equal(1))
+
+		calls = 0
+		vpcbetav1.CollectionItems[string](context.Background(), nil, nil)(func(item string, err error) bool {
+			calls++
+			Expect(err).ToNot(BeNil())
+			return true
+		})
+		Expect(calls).To(Equal(1))
+	})
+})
@@ -0,0 +1,250 @@
+//go:build go1.21
+
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/vpc-beta-go-sdk/common"
+)
+
+// RedactedValue replaces the values masked by a LoggingTransport.
+const RedactedValue = "[REDACTED]"
+
+// DefaultRedactedHeaders are the headers masked in logs, unless configured otherwise.
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Auth-Refresh-Token"}
+
+// DefaultRedactedFields are the JSON properties masked in logged bodies, at any depth, unless
+// configured otherwise: user data and keys of instances, pre-shared keys of VPN gateway
+// connections and credentials.
+var DefaultRedactedFields = []string{"user_data", "public_key", "private_key", "psk", "passphrase",
+	"password", "apikey", "access_token", "refresh_token"}
+
+// DefaultRedactedOperations are the operations whose bodies are never logged, unless
+// configured otherwise: VPN server client configurations embed client keys.
+var DefaultRedactedOperations = []string{"get_vpn_server_client_configuration"}
+
+// LoggingOptions : The LoggingOptions struct configures a LoggingTransport.
+type LoggingOptions struct {
+	// The logger. If nil, slog.Default() is used.
+	Logger *slog.Logger
+
+	// The level of successful requests. If nil, slog.LevelDebug is used.
+	Level slog.Leveler
+
+	// The level of failed requests (transport errors and 4xx/5xx responses). If nil,
+	// slog.LevelWarn is used.
+	ErrorLevel slog.Leveler
+
+	// Whether to log the request and response headers.
+	LogHeaders bool
+
+	// Whether to log the request and response bodies.
+	LogBodies bool
+
+	// The headers, JSON properties and operations to redact. If nil, DefaultRedactedHeaders,
+	// DefaultRedactedFields and DefaultRedactedOperations are used.
+	RedactedHeaders    []string
+	RedactedFields     []string
+	RedactedOperations []string
+}
+
+// LoggingTransport : An http.RoundTripper that logs every request with log/slog: its
+// operation, method, path, status, duration and request ID, and optionally its headers and
+// bodies, with secrets redacted. To use it, wrap the transport of the service's HTTP client:
+//
+//	transport := vpcbetav1.NewLoggingTransport(nil, &vpcbetav1.LoggingOptions{Logger: logger})
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: transport})
+type LoggingTransport struct {
+	// The base transport. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	options LoggingOptions
+}
+
+// NewLoggingTransport returns a new LoggingTransport wrapping the given transport.
+func NewLoggingTransport(transport http.RoundTripper, options *LoggingOptions) *LoggingTransport {
+	if options == nil {
+		options = &LoggingOptions{}
+	}
+	logging := &LoggingTransport{Transport: transport, options: *options}
+	if logging.options.Logger == nil {
+		logging.options.Logger = slog.Default()
+	}
+	if logging.options.Level == nil {
+		logging.options.Level = slog.LevelDebug
+	}
+	if logging.options.ErrorLevel == nil {
+		logging.options.ErrorLevel = slog.LevelWarn
+	}
+	if logging.options.RedactedHeaders == nil {
+		logging.options.RedactedHeaders = DefaultRedactedHeaders
+	}
+	if logging.options.RedactedFields == nil {
+		logging.options.RedactedFields = DefaultRedactedFields
+	}
+	if logging.options.RedactedOperations == nil {
+		logging.options.RedactedOperations = DefaultRedactedOperations
+	}
+	return logging
+}
+
+// RoundTrip sends the request and logs it.
+func (transport *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := transport.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	options := &transport.options
+	ctx := req.Context()
+	if !options.Logger.Enabled(ctx, options.Level.Level()) && !options.Logger.Enabled(ctx, options.ErrorLevel.Level()) {
+		return base.RoundTrip(req)
+	}
+
+	operationID := operationIDOf(req)
+	redactBodies := false
+	for _, operation := range options.RedactedOperations {
+		redactBodies = redactBodies || operation == operationID
+	}
+
+	var reqBody []byte
+	if options.LogBodies {
+		var err error
+		if req, reqBody, err = readRequestBody(req); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	res, err := base.RoundTrip(req)
+	duration := time.Since(start)
+
+	attrs := []slog.Attr{
+		slog.String("operation", operationID),
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+	}
+	level := options.Level.Level()
+	if err != nil {
+		level = options.ErrorLevel.Level()
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if res.StatusCode >= 400 {
+			level = options.ErrorLevel.Level()
+		}
+	}
+	attrs = append(attrs, slog.Duration("duration", duration))
+	requestID := req.Header.Get(common.X_REQUEST_ID)
+	if requestID == "" && res != nil {
+		requestID = res.Header.Get(common.X_REQUEST_ID)
+	}
+	if requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	if !options.Logger.Enabled(ctx, level) {
+		return res, err
+	}
+
+	if options.LogHeaders {
+		attrs = append(attrs, slog.Any("request_headers", transport.redactHeaders(req.Header)))
+		if res != nil {
+			attrs = append(attrs, slog.Any("response_headers", transport.redactHeaders(res.Header)))
+		}
+	}
+	if options.LogBodies {
+		if len(reqBody) > 0 {
+			attrs = append(attrs, slog.String("request_body", transport.redactBody(reqBody, redactBodies)))
+		}
+		if res != nil {
+			resBody, readErr := io.ReadAll(res.Body)
+			_ = res.Body.Close()
+			if readErr != nil {
+				return nil, readErr
+			}
+			res.Body = io.NopCloser(bytes.NewReader(resBody))
+			if len(resBody) > 0 {
+				attrs = append(attrs, slog.String("response_body", transport.redactBody(resBody, redactBodies)))
+			}
+		}
+	}
+
+	options.Logger.LogAttrs(ctx, level, "vpc request", attrs...)
+	return res, err
+}
+
+// redactHeaders returns a copy of the headers with the values of the redacted ones masked.
+func (transport *LoggingTransport) redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for name := range redacted {
+		for _, redactedName := range transport.options.RedactedHeaders {
+			if strings.EqualFold(name, redactedName) {
+				redacted[name] = []string{RedactedValue}
+			}
+		}
+	}
+	return redacted
+}
+
+// redactBody returns a body with the values of the redacted JSON properties masked, or
+// masks it entirely. Bodies that are not valid JSON are masked entirely, since their secrets
+// cannot be found.
+func (transport *LoggingTransport) redactBody(body []byte, redactAll bool) string {
+	if redactAll {
+		return RedactedValue
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return RedactedValue
+	}
+	buf, err := json.Marshal(transport.redactValue(value))
+	if err != nil {
+		return RedactedValue
+	}
+	return string(buf)
+}
+
+// redactValue masks the values of the redacted properties of a JSON value, at any depth.
+func (transport *LoggingTransport) redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, property := range value {
+			redacted := false
+			for _, field := range transport.options.RedactedFields {
+				redacted = redacted || key == field
+			}
+			if redacted {
+				value[key] = RedactedValue
+			} else {
+				value[key] = transport.redactValue(property)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = transport.redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,163 @@
+//go:build go1.21
+
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`LoggingTransport`, func() {
+	var server *httptest.Server
+	var service *core.BaseService
+	var output *bytes.Buffer
+
+	// records returns the logged records.
+	records := func() (result []map[string]interface{}) {
+		for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var record map[string]interface{}
+			Expect(json.Unmarshal([]byte(line), &record)).To(Succeed())
+			result = append(result, record)
+		}
+		return
+	}
+	useLogging := func(options *vpcbetav1.LoggingOptions) {
+		options.Logger = slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		service.SetHTTPClient(&http.Client{Transport: vpcbetav1.NewLoggingTransport(nil, options)})
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/client_configuration"):
+				res.Header().Set("Content-Type", "text/plain")
+				fmt.Fprint(res, "client\n<key>\nsecret-client-key\n</key>")
+			case req.URL.Path == "/v1/instances" && req.Method == http.MethodPost:
+				res.Header().Set("Content-Type", "application/json")
+				res.WriteHeader(http.StatusCreated)
+				fmt.Fprint(res, `{"id":"instance-1","user_data":"#!/bin/sh secret","keys":[{"id":"key-1","public_key":"ssh-rsa AAAA"}]}`)
+			case req.URL.Path == "/v1/keys":
+				res.Header().Set("Content-Type", "application/json")
+				res.WriteHeader(http.StatusCreated)
+				fmt.Fprint(res, `{"id":"key-1","public_key":"ssh-rsa AAAA`)
+			default:
+				res.Header().Set("Content-Type", "application/json")
+				res.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(res, `{"errors":[{"code":"not_found","message":"Not found."}]}`)
+			}
+		}))
+		service = newTestService(server.URL + "/v1")
+		service.Options.Authenticator = &core.BearerTokenAuthenticator{BearerToken: "secret-token"}
+		output = &bytes.Buffer{}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Logs requests with secrets redacted`, func() {
+		useLogging(&vpcbetav1.LoggingOptions{LogHeaders: true, LogBodies: true})
+		_, _, err := invoke(service, core.POST, "/instances", map[string]interface{}{
+			"name":      "my-instance",
+			"user_data": "#!/bin/sh secret",
+			"vpn":       map[string]interface{}{"psk": "lkj14b1oi0alcniejkso"},
+		})
+		Expect(err).To(BeNil())
+
+		logged := output.String()
+		for _, secret := range []string{"secret-token", "#!/bin/sh secret", "ssh-rsa AAAA", "lkj14b1oi0alcniejkso"} {
+			Expect(logged).ToNot(ContainSubstring(secret))
+		}
+		record := records()[0]
+		Expect(record["level"]).To(Equal("DEBUG"))
+		Expect(record["msg"]).To(Equal("vpc request"))
+		Expect(record["operation"]).To(Equal("create_instance"))
+		Expect(record["method"]).To(Equal("POST"))
+		Expect(record["path"]).To(Equal("/v1/instances"))
+		Expect(record["status"]).To(Equal(float64(201)))
+		Expect(record).To(HaveKey("duration"))
+		Expect(record["request_id"]).ToNot(BeEmpty())
+		Expect(record["request_id"]).To(Equal(record["request_headers"].(map[string]interface{})["X-Request-Id"].([]interface{})[0]))
+		Expect(record["request_headers"].(map[string]interface{})["Authorization"]).To(Equal([]interface{}{"[REDACTED]"}))
+		Expect(record["request_body"]).To(ContainSubstring(`"name":"my-instance"`))
+		Expect(record["request_body"]).To(ContainSubstring(`"psk":"[REDACTED]"`))
+		Expect(record["response_body"]).To(ContainSubstring(`"public_key":"[REDACTED]"`))
+	})
+	It(`Redacts VPN server client configurations`, func() {
+		useLogging(&vpcbetav1.LoggingOptions{LogBodies: true})
+		builder := core.NewRequestBuilder(core.GET)
+		_, err := builder.ResolveRequestURL(service.Options.URL, "/vpn_servers/server-1/client_configuration", nil)
+		Expect(err).To(BeNil())
+		request, err := builder.Build()
+		Expect(err).To(BeNil())
+		var result *string
+		_, err = service.Request(request, &result)
+		Expect(err).To(BeNil())
+
+		Expect(output.String()).ToNot(ContainSubstring("secret-client-key"))
+		record := records()[0]
+		Expect(record["operation"]).To(Equal("get_vpn_server_client_configuration"))
+		Expect(record["response_body"]).To(Equal("[REDACTED]"))
+	})
+	It(`Redacts bodies that are not valid JSON`, func() {
+		useLogging(&vpcbetav1.LoggingOptions{LogBodies: true})
+		builder := core.NewRequestBuilder(core.POST)
+		_, err := builder.ResolveRequestURL(service.Options.URL, "/keys", nil)
+		Expect(err).To(BeNil())
+		_, err = builder.SetBodyContentString(`{"name":"my-key","public_key":"ssh-rsa AAAA`)
+		Expect(err).To(BeNil())
+		request, err := builder.Build()
+		Expect(err).To(BeNil())
+		var result *string
+		service.Request(request, &result)
+
+		Expect(output.String()).ToNot(ContainSubstring("ssh-rsa AAAA"))
+		record := records()[0]
+		Expect(record["request_body"]).To(Equal("[REDACTED]"))
+		Expect(record["response_body"]).To(Equal("[REDACTED]"))
+	})
+	It(`Logs failed requests at the error level only`, func() {
+		useLogging(&vpcbetav1.LoggingOptions{Level: slog.LevelInfo - 8, ErrorLevel: slog.LevelError})
+		_, _, err := invoke(service, core.GET, "/vpcs/missing", nil)
+		Expect(err).ToNot(BeNil())
+		record := records()[0]
+		Expect(record["level"]).To(Equal("ERROR"))
+		Expect(record["status"]).To(Equal(float64(404)))
+		Expect(record).ToNot(HaveKey("request_headers"))
+		Expect(record).ToNot(HaveKey("response_body"))
+
+		// Successful requests are below the level of the handler.
+		output.Reset()
+		_, _, err = invoke(service, core.POST, "/instances", map[string]interface{}{"name": "my-instance"})
+		Expect(err).To(BeNil())
+		Expect(output.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,300 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultMultiRegionConcurrency is the number of regions queried in parallel, unless
+// configured otherwise.
+const DefaultMultiRegionConcurrency = 4
+
+// RegionStatusAvailable is the status of the regions that can be used.
+const RegionStatusAvailable = "available"
+
+// RegionInfo : A region, as returned by the ListRegions operation.
+type RegionInfo struct {
+	// The name of the region (for example `us-south`).
+	Name string `json:"name"`
+
+	// The API endpoint of the region (for example `https://us-south.iaas.cloud.ibm.com`).
+	Endpoint string `json:"endpoint"`
+
+	// The status of the region.
+	Status string `json:"status"`
+}
+
+// ServiceURL returns the service URL of the region, to use as the URL of a client.
+func (region *RegionInfo) ServiceURL() string {
+	return strings.TrimSuffix(region.Endpoint, "/") + "/v1"
+}
+
+// RegionListFunc lists the regions, usually with the generated ListRegionsWithContext
+// operation. The collection must have a `regions` property, as RegionCollection does.
+type RegionListFunc func(ctx context.Context) (collection interface{}, response *core.DetailedResponse, err error)
+
+// MultiRegionOptions : The MultiRegionOptions struct configures a MultiRegionClient.
+type MultiRegionOptions struct {
+	// The names of the regions to use. If empty, all available regions are used.
+	Regions []string
+
+	// The number of regions queried in parallel. If not set, DefaultMultiRegionConcurrency
+	// is used.
+	Concurrency int
+}
+
+// RegionError : The error of one region.
+type RegionError struct {
+	Region string
+	Err    error
+}
+
+// Error returns the error message.
+func (regionErr *RegionError) Error() string {
+	return fmt.Sprintf("%s: %s", regionErr.Region, regionErr.Err.Error())
+}
+
+// Unwrap returns the error of the region.
+func (regionErr *RegionError) Unwrap() error {
+	return regionErr.Err
+}
+
+// MultiRegionError : The error returned by fan-out operations when some regions failed.
+type MultiRegionError struct {
+	// The errors, ordered by region.
+	Errors []*RegionError
+}
+
+// Error returns the error message.
+func (multiErr *MultiRegionError) Error() string {
+	msgs := make([]string, len(multiErr.Errors))
+	for i, regionErr := range multiErr.Errors {
+		msgs[i] = regionErr.Error()
+	}
+	return fmt.Sprintf("%d region(s) failed: %s", len(msgs), strings.Join(msgs, "; "))
+}
+
+// RegionItem : An item of a merged result, tagged with its region.
+type RegionItem[T any] struct {
+	Region string
+	Item   T
+}
+
+// MultiRegionClient : A set of clients, one per region, created on first use. The regions are
+// discovered with the ListRegions operation:
+//
+//	authenticator := &core.IamAuthenticator{ApiKey: apiKey}
+//	regionService, _ := vpcbetav1.NewVpcbetaV1(&vpcbetav1.VpcbetaV1Options{Authenticator: authenticator})
+//	client, _ := vpcbetav1.NewMultiRegionClient(
+//		func(ctx context.Context) (interface{}, *core.DetailedResponse, error) {
+//			return regionService.ListRegionsWithContext(ctx, &vpcbetav1.ListRegionsOptions{})
+//		},
+//		func(region *vpcbetav1.RegionInfo) (*vpcbetav1.VpcbetaV1, error) {
+//			return vpcbetav1.NewVpcbetaV1(&vpcbetav1.VpcbetaV1Options{URL: region.ServiceURL(), Authenticator: authenticator})
+//		}, nil)
+//
+// Sharing the authenticator between the clients means a single IAM token is fetched and
+// refreshed for all regions.
+type MultiRegionClient[C any] struct {
+	listRegions RegionListFunc
+	newClient   func(region *RegionInfo) (C, error)
+	options     MultiRegionOptions
+
+	mutex   sync.Mutex
+	regions []*RegionInfo
+	clients map[string]C
+}
+
+// NewMultiRegionClient returns a new MultiRegionClient.
+func NewMultiRegionClient[C any](listRegions RegionListFunc, newClient func(region *RegionInfo) (C, error), options *MultiRegionOptions) (client *MultiRegionClient[C], err error) {
+	if listRegions == nil || newClient == nil {
+		err = fmt.Errorf("the region list and client functions must be set")
+		return
+	}
+	if options == nil {
+		options = &MultiRegionOptions{}
+	}
+	client = &MultiRegionClient[C]{
+		listRegions: listRegions,
+		newClient:   newClient,
+		options:     *options,
+		clients:     make(map[string]C),
+	}
+	return
+}
+
+// Regions returns the regions used by the client: the available regions, or the regions
+// configured in the options. The regions are listed on first use only.
+func (client *MultiRegionClient[C]) Regions(ctx context.Context) ([]*RegionInfo, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.regions != nil {
+		return client.regions, nil
+	}
+
+	collection, _, err := client.listRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := json.Marshal(collection)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Regions []*RegionInfo `json:"regions"`
+	}
+	if err = json.Unmarshal(buf, &result); err != nil {
+		return nil, err
+	}
+
+	regions := []*RegionInfo{}
+	for _, region := range result.Regions {
+		if len(client.options.Regions) > 0 {
+			if core.SliceContains(client.options.Regions, region.Name) {
+				regions = append(regions, region)
+			}
+		} else if region.Status == RegionStatusAvailable {
+			regions = append(regions, region)
+		}
+	}
+	for _, name := range client.options.Regions {
+		found := false
+		for _, region := range regions {
+			found = found || region.Name == name
+		}
+		if !found {
+			return nil, fmt.Errorf("region '%s' does not exist", name)
+		}
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Name < regions[j].Name })
+	client.regions = regions
+	return regions, nil
+}
+
+// Client returns the client of a region, creating it on first use.
+func (client *MultiRegionClient[C]) Client(ctx context.Context, name string) (regionClient C, err error) {
+	regions, err := client.Regions(ctx)
+	if err != nil {
+		return
+	}
+	for _, region := range regions {
+		if region.Name == name {
+			return client.clientOf(region)
+		}
+	}
+	err = fmt.Errorf("region '%s' is not used by the client", name)
+	return
+}
+
+// clientOf returns the client of a region, creating it on first use.
+func (client *MultiRegionClient[C]) clientOf(region *RegionInfo) (regionClient C, err error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	regionClient, ok := client.clients[region.Name]
+	if ok {
+		return
+	}
+	if regionClient, err = client.newClient(region); err != nil {
+		return
+	}
+	client.clients[region.Name] = regionClient
+	return
+}
+
+// ForEachRegion calls the function for each region, with a bounded number of regions in
+// parallel. Every region is visited even if some fail; the errors are returned as a
+// *MultiRegionError.
+func (client *MultiRegionClient[C]) ForEachRegion(ctx context.Context, fn func(ctx context.Context, region *RegionInfo, regionClient C) error) error {
+	regions, err := client.Regions(ctx)
+	if err != nil {
+		return err
+	}
+	concurrency := client.options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultMultiRegionConcurrency
+	}
+
+	errs := make([]error, len(regions))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region *RegionInfo) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-semaphore }()
+
+			regionClient, err := client.clientOf(region)
+			if err == nil {
+				err = fn(ctx, region, regionClient)
+			}
+			errs[i] = err
+		}(i, region)
+	}
+	wg.Wait()
+
+	multiErr := &MultiRegionError{}
+	for i, err := range errs {
+		if err != nil {
+			multiErr.Errors = append(multiErr.Errors, &RegionError{Region: regions[i].Name, Err: err})
+		}
+	}
+	if len(multiErr.Errors) > 0 {
+		return multiErr
+	}
+	return nil
+}
+
+// ListAllRegions calls a list function, such as one collecting all instances or VPCs of a
+// region, in each region and merges the results, tagged by region and ordered by region.
+// The results of the regions that succeeded are returned even if some failed, along with
+// a *MultiRegionError.
+func ListAllRegions[C any, T any](ctx context.Context, client *MultiRegionClient[C], list func(ctx context.Context, regionClient C) ([]T, error)) (items []RegionItem[T], err error) {
+	var mutex sync.Mutex
+	byRegion := make(map[string][]T)
+	err = client.ForEachRegion(ctx, func(ctx context.Context, region *RegionInfo, regionClient C) error {
+		regionItems, err := list(ctx, regionClient)
+		if err != nil {
+			return err
+		}
+		mutex.Lock()
+		byRegion[region.Name] = regionItems
+		mutex.Unlock()
+		return nil
+	})
+
+	regions, _ := client.Regions(ctx)
+	for _, region := range regions {
+		for _, item := range byRegion[region.Name] {
+			items = append(items, RegionItem[T]{Region: region.Name, Item: item})
+		}
+	}
+	return
+}
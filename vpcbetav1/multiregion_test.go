@@ -0,0 +1,162 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`MultiRegionClient`, func() {
+	var servers map[string]*vpcfake.Server
+	var created []string
+	var listRegions vpcbetav1.RegionListFunc
+	authenticator := &core.NoAuthAuthenticator{}
+
+	newClient := func(region *vpcbetav1.RegionInfo) (*core.BaseService, error) {
+		created = append(created, region.Name)
+		return core.NewBaseService(&core.ServiceOptions{URL: region.ServiceURL(), Authenticator: authenticator})
+	}
+	listVPCs := func(ctx context.Context, service *core.BaseService) ([]string, error) {
+		result, _, err := invoke(service, core.GET, "/vpcs", nil)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, vpc := range result["vpcs"].([]interface{}) {
+			names = append(names, vpc.(map[string]interface{})["name"].(string))
+		}
+		return names, nil
+	}
+
+	BeforeEach(func() {
+		servers = map[string]*vpcfake.Server{}
+		regions := []interface{}{}
+		for _, name := range []string{"us-south", "eu-de", "jp-tok"} {
+			servers[name] = vpcfake.NewServer()
+			regions = append(regions, map[string]interface{}{
+				"name": name, "endpoint": servers[name].URL, "status": "available",
+			})
+		}
+		regions = append(regions, map[string]interface{}{
+			"name": "br-sao", "endpoint": "https://br-sao.invalid", "status": "unavailable",
+		})
+		created = nil
+		listRegions = func(ctx context.Context) (interface{}, *core.DetailedResponse, error) {
+			return map[string]interface{}{"regions": regions}, nil, nil
+		}
+	})
+	AfterEach(func() {
+		for _, server := range servers {
+			server.Close()
+		}
+	})
+
+	It(`Discovers regions and creates clients lazily`, func() {
+		client, err := vpcbetav1.NewMultiRegionClient(listRegions, newClient, nil)
+		Expect(err).To(BeNil())
+		regions, err := client.Regions(context.Background())
+		Expect(err).To(BeNil())
+		Expect(regions).To(HaveLen(3))
+		Expect(regions[0].Name).To(Equal("eu-de"))
+		Expect(regions[0].ServiceURL()).To(Equal(servers["eu-de"].ServiceURL()))
+		Expect(created).To(BeEmpty())
+
+		first, err := client.Client(context.Background(), "us-south")
+		Expect(err).To(BeNil())
+		second, err := client.Client(context.Background(), "us-south")
+		Expect(err).To(BeNil())
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(created).To(Equal([]string{"us-south"}))
+
+		_, err = client.Client(context.Background(), "br-sao")
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Merges results tagged by region`, func() {
+		for name, server := range servers {
+			_, _, err := invoke(newTestService(server.ServiceURL()), core.POST, "/vpcs", map[string]interface{}{"name": name + "-vpc"})
+			Expect(err).To(BeNil())
+		}
+		client, err := vpcbetav1.NewMultiRegionClient(listRegions, newClient, &vpcbetav1.MultiRegionOptions{
+			Regions: []string{"us-south", "jp-tok"},
+		})
+		Expect(err).To(BeNil())
+
+		items, err := vpcbetav1.ListAllRegions(context.Background(), client, listVPCs)
+		Expect(err).To(BeNil())
+		Expect(items).To(Equal([]vpcbetav1.RegionItem[string]{
+			{Region: "jp-tok", Item: "jp-tok-vpc"},
+			{Region: "us-south", Item: "us-south-vpc"},
+		}))
+	})
+	It(`Aggregates the errors of the regions`, func() {
+		_, _, err := invoke(newTestService(servers["us-south"].ServiceURL()), core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		servers["eu-de"].Close()
+
+		client, err := vpcbetav1.NewMultiRegionClient(listRegions, newClient, nil)
+		Expect(err).To(BeNil())
+		items, err := vpcbetav1.ListAllRegions(context.Background(), client, listVPCs)
+		Expect(items).To(Equal([]vpcbetav1.RegionItem[string]{{Region: "us-south", Item: "my-vpc"}}))
+
+		var multiErr *vpcbetav1.MultiRegionError
+		Expect(errors.As(err, &multiErr)).To(BeTrue())
+		Expect(multiErr.Errors).To(HaveLen(1))
+		Expect(multiErr.Errors[0].Region).To(Equal("eu-de"))
+		Expect(err.Error()).To(HavePrefix("1 region(s) failed: eu-de: "))
+	})
+	It(`Bounds the number of regions queried in parallel`, func() {
+		client, err := vpcbetav1.NewMultiRegionClient(listRegions, newClient, &vpcbetav1.MultiRegionOptions{Concurrency: 2})
+		Expect(err).To(BeNil())
+		var mutex sync.Mutex
+		running, maxRunning := 0, 0
+		err = client.ForEachRegion(context.Background(), func(ctx context.Context, region *vpcbetav1.RegionInfo, service *core.BaseService) error {
+			mutex.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mutex.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+			return nil
+		})
+		Expect(err).To(BeNil())
+		Expect(maxRunning).To(Equal(2))
+	})
+	It(`Invoke NewMultiRegionClient with error`, func() {
+		_, err := vpcbetav1.NewMultiRegionClient[*core.BaseService](nil, newClient, nil)
+		Expect(err).ToNot(BeNil())
+
+		client, err := vpcbetav1.NewMultiRegionClient(listRegions, newClient, &vpcbetav1.MultiRegionOptions{
+			Regions: []string{"mars-1"},
+		})
+		Expect(err).To(BeNil())
+		_, err = client.Regions(context.Background())
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,507 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// The actions of the calls of a network ACL rule synchronization.
+const (
+	NetworkACLRuleActionCreate = "create"
+	NetworkACLRuleActionUpdate = "update"
+	NetworkACLRuleActionDelete = "delete"
+)
+
+// NormalizedNetworkACLRule : A network ACL rule normalized from any of the polymorphic rule,
+// rule item or rule prototype models, so that rules can be compared: the defaults of the API
+// are made explicit (IP version `ipv4` and ports 1 to 65535 for TCP and UDP) and sources and
+// destinations are canonical CIDR blocks (see ParseAddressOrCIDR).
+type NormalizedNetworkACLRule struct {
+	// The unique identifier of an existing rule.
+	ID string
+
+	// The name of the rule. Desired rules without a name match rules of any name.
+	Name string
+
+	Action      string
+	Direction   string
+	IPVersion   string
+	Protocol    string
+	Source      string
+	Destination string
+
+	// The port ranges of TCP and UDP rules.
+	SourcePortMin      *int64
+	SourcePortMax      *int64
+	DestinationPortMin *int64
+	DestinationPortMax *int64
+
+	// The ICMP type and code of ICMP rules, if any.
+	Type *int64
+	Code *int64
+}
+
+// NormalizeNetworkACLRule returns the normalized form of a network ACL rule model
+// (NetworkACLRule..., NetworkACLRuleItem..., NetworkACLRulePrototype... or a JSON object of the
+// same shape).
+func NormalizeNetworkACLRule(model interface{}) (rule *NormalizedNetworkACLRule, err error) {
+	object, err := ToJSONObject(model)
+	if err != nil {
+		return
+	}
+	rule = &NormalizedNetworkACLRule{IPVersion: "ipv4"}
+	rule.ID, _ = object["id"].(string)
+	rule.Name, _ = object["name"].(string)
+	rule.Action, _ = object["action"].(string)
+	rule.Direction, _ = object["direction"].(string)
+	rule.Protocol, _ = object["protocol"].(string)
+	rule.Protocol = strings.ToLower(rule.Protocol)
+	if ipVersion, _ := object["ip_version"].(string); ipVersion != "" {
+		rule.IPVersion = ipVersion
+	}
+	if rule.Action == "" || rule.Direction == "" || rule.Protocol == "" {
+		return nil, fmt.Errorf("network ACL rule %v has no action, direction or protocol", object)
+	}
+	for _, cidr := range []*string{&rule.Source, &rule.Destination} {
+		key := "source"
+		if cidr == &rule.Destination {
+			key = "destination"
+		}
+		value, _ := object[key].(string)
+		prefix, parseErr := ParseAddressOrCIDR(value)
+		if parseErr != nil {
+			return nil, fmt.Errorf("network ACL rule %v has an invalid %s: %w", object, key, parseErr)
+		}
+		*cidr = prefix.String()
+	}
+
+	integer := func(key string, defaultValue *int64) *int64 {
+		if value, ok := object[key].(float64); ok {
+			return core.Int64Ptr(int64(value))
+		}
+		return defaultValue
+	}
+	switch rule.Protocol {
+	case "tcp", "udp":
+		rule.SourcePortMin = integer("source_port_min", core.Int64Ptr(1))
+		rule.SourcePortMax = integer("source_port_max", core.Int64Ptr(65535))
+		rule.DestinationPortMin = integer("destination_port_min", core.Int64Ptr(1))
+		rule.DestinationPortMax = integer("destination_port_max", core.Int64Ptr(65535))
+	case "icmp":
+		rule.Type, rule.Code = integer("type", nil), integer("code", nil)
+	}
+	return
+}
+
+// ParseAddressOrCIDR parses an IP address or a CIDR block, as accepted for the source and
+// destination of network ACL rules. An address is returned as a host prefix (`/32`, or `/128`
+// for IPv6), and a CIDR block with its host bits cleared.
+func ParseAddressOrCIDR(value string) (prefix netip.Prefix, err error) {
+	if addr, addrErr := netip.ParseAddr(value); addrErr == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	if prefix, err = netip.ParsePrefix(value); err != nil {
+		return
+	}
+	return prefix.Masked(), nil
+}
+
+// sameContent returns true if both rules have the same effect, whatever their names.
+func (rule *NormalizedNetworkACLRule) sameContent(other *NormalizedNetworkACLRule) bool {
+	return rule.Action == other.Action && rule.Direction == other.Direction &&
+		rule.IPVersion == other.IPVersion && rule.Protocol == other.Protocol &&
+		rule.Source == other.Source && rule.Destination == other.Destination &&
+		equalInt64Ptr(rule.SourcePortMin, other.SourcePortMin) &&
+		equalInt64Ptr(rule.SourcePortMax, other.SourcePortMax) &&
+		equalInt64Ptr(rule.DestinationPortMin, other.DestinationPortMin) &&
+		equalInt64Ptr(rule.DestinationPortMax, other.DestinationPortMax) &&
+		equalInt64Ptr(rule.Type, other.Type) && equalInt64Ptr(rule.Code, other.Code)
+}
+
+// Matches returns true if the rule has the same effect as the desired one, and its name if the
+// desired rule has one.
+func (rule *NormalizedNetworkACLRule) Matches(desired *NormalizedNetworkACLRule) bool {
+	return rule.sameContent(desired) && (desired.Name == "" || rule.Name == desired.Name)
+}
+
+// String returns a short description of the rule, such as `allow inbound tcp 10.0.0.0/8 ->
+// 0.0.0.0/0 port 22-22`.
+func (rule *NormalizedNetworkACLRule) String() string {
+	description := fmt.Sprintf("%s %s %s %s -> %s", rule.Action, rule.Direction, rule.Protocol, rule.Source, rule.Destination)
+	if rule.DestinationPortMin != nil {
+		description += fmt.Sprintf(" port %d-%d", *rule.DestinationPortMin, *rule.DestinationPortMax)
+	}
+	if rule.Type != nil {
+		description += fmt.Sprintf(" type %d", *rule.Type)
+	}
+	if rule.Code != nil {
+		description += fmt.Sprintf(" code %d", *rule.Code)
+	}
+	return description
+}
+
+// patchTo returns the patch updating the rule to the desired one, which has the same protocol.
+func (rule *NormalizedNetworkACLRule) patchTo(desired *NormalizedNetworkACLRule) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key, values := range map[string][2]string{"name": {rule.Name, desired.Name},
+		"action": {rule.Action, desired.Action}, "direction": {rule.Direction, desired.Direction},
+		"ip_version": {rule.IPVersion, desired.IPVersion}, "source": {rule.Source, desired.Source},
+		"destination": {rule.Destination, desired.Destination}} {
+		if values[0] != values[1] && values[1] != "" {
+			patch[key] = values[1]
+		}
+	}
+	for key, values := range map[string][2]*int64{
+		"source_port_min": {rule.SourcePortMin, desired.SourcePortMin}, "source_port_max": {rule.SourcePortMax, desired.SourcePortMax},
+		"destination_port_min": {rule.DestinationPortMin, desired.DestinationPortMin},
+		"destination_port_max": {rule.DestinationPortMax, desired.DestinationPortMax},
+		"type":                 {rule.Type, desired.Type}, "code": {rule.Code, desired.Code}} {
+		if !equalInt64Ptr(values[0], values[1]) {
+			if values[1] == nil {
+				patch[key] = nil
+			} else {
+				patch[key] = *values[1]
+			}
+		}
+	}
+	return patch
+}
+
+// NetworkACLRuleSyncOptions : The NetworkACLRuleSyncOptions struct configures
+// SyncNetworkACLRules. The functions are usually thin wrappers around the generated
+// ListNetworkACLRulesWithContext, CreateNetworkACLRuleWithContext,
+// UpdateNetworkACLRuleWithContext and DeleteNetworkACLRuleWithContext operations.
+type NetworkACLRuleSyncOptions struct {
+	// Lists one page of the rules of a network ACL (the NetworkACLRuleCollection model),
+	// starting at the given `start` token (nil for the first page).
+	List func(ctx context.Context, networkACLID string, start *string) (collection interface{}, response *core.DetailedResponse, err error)
+
+	// Creates a rule from one of the desired rule prototypes, immediately before the rule with
+	// the given ID (setting the Before property of the prototype), or after all rules if
+	// before is empty.
+	Create func(ctx context.Context, networkACLID string, prototype interface{}, before string) (rule interface{}, response *core.DetailedResponse, err error)
+
+	// Updates a rule with a patch (see NetworkACLRulePatch.AsPatch). A `before` property of
+	// nil moves the rule after all rules.
+	Update func(ctx context.Context, networkACLID string, ruleID string, patch map[string]interface{}) (rule interface{}, response *core.DetailedResponse, err error)
+
+	// Deletes a rule.
+	Delete func(ctx context.Context, networkACLID string, ruleID string) (response *core.DetailedResponse, err error)
+
+	// If true, nothing is changed: the report only lists the calls to make.
+	PlanOnly bool
+}
+
+// NetworkACLRuleCall : A call of a network ACL rule synchronization.
+type NetworkACLRuleCall struct {
+	// The action (one of the NetworkACLRuleAction... constants).
+	Action string
+
+	// The current rule, for updates and deletions.
+	Current *NormalizedNetworkACLRule
+
+	// The desired rule, for creations and updates, its prototype and its index in the desired
+	// rules.
+	Desired   *NormalizedNetworkACLRule
+	Prototype interface{}
+	Index     int
+
+	// The patch of an update, without its `before` property.
+	Patch map[string]interface{}
+
+	// Whether the call moves the rule (or places the created rule): immediately before the
+	// desired rule with index BeforeIndex, or after all rules if BeforeIndex is -1.
+	Move        bool
+	BeforeIndex int
+
+	// The ID of the rule: the created rule, or the updated or deleted one.
+	RuleID string
+
+	// Whether the call was made (false in plan-only mode).
+	Applied bool
+
+	// Whether the call was skipped because a previous call failed.
+	Skipped bool
+
+	// The error of the call, if any.
+	Err error
+}
+
+// String returns a short description of the call.
+func (call *NetworkACLRuleCall) String() string {
+	rule := call.Desired
+	if rule == nil {
+		rule = call.Current
+	}
+	description := call.Action + " " + rule.String()
+	if call.Move {
+		if call.BeforeIndex < 0 {
+			description += " at the end"
+		} else {
+			description += fmt.Sprintf(" before #%d", call.BeforeIndex)
+		}
+	}
+	return description
+}
+
+// NetworkACLRuleSyncReport : The outcome of a network ACL rule synchronization.
+type NetworkACLRuleSyncReport struct {
+	// The calls, in the order they are made: creations and updates from the last desired rule
+	// to the first, then deletions.
+	Calls []*NetworkACLRuleCall
+
+	// The current rules that already match a desired rule and its position.
+	Unchanged []*NormalizedNetworkACLRule
+}
+
+// SyncNetworkACLRules makes the rules of a network ACL match the desired, ordered rule
+// prototypes (NetworkACLRulePrototype... models or JSON objects of the same shape), including
+// the default rules: after the synchronization, the network ACL has exactly the desired rules,
+// in the desired order.
+//
+// Current and desired rules are normalized (see NormalizeNetworkACLRule) and matched: current
+// rules matching a desired rule are kept, current rules with the same protocol as a missing
+// desired rule are updated, the remaining desired rules are created and the remaining current
+// rules are deleted. Only the kept and updated rules that are not part of the longest sequence
+// already in the desired order are moved, so the sequence of calls is minimal. The desired
+// rules are put in place before the remaining rules are deleted, so that no traffic is
+// wrongly allowed or denied in the meantime.
+//
+// The calls depend on each other, so they are made one at a time and the first failure stops
+// the synchronization: the report lists every call, and the error of the failed one is
+// returned along with it.
+func SyncNetworkACLRules(ctx context.Context, networkACLID string, desired []interface{}, options *NetworkACLRuleSyncOptions) (report *NetworkACLRuleSyncReport, err error) {
+	if options == nil || options.List == nil || options.Create == nil || options.Update == nil || options.Delete == nil {
+		err = fmt.Errorf("list, create, update and delete functions cannot be nil")
+		return
+	}
+	current, err := listNetworkACLRules(ctx, networkACLID, options.List)
+	if err != nil {
+		return
+	}
+	desiredRules := make([]*NormalizedNetworkACLRule, len(desired))
+	for i, prototype := range desired {
+		if desiredRules[i], err = NormalizeNetworkACLRule(prototype); err != nil {
+			return
+		}
+	}
+
+	report, ids := planNetworkACLRules(current, desiredRules, desired)
+	if options.PlanOnly {
+		return
+	}
+
+	for i, call := range report.Calls {
+		if err = ctx.Err(); err != nil {
+			call.Err = err
+		} else {
+			call.Err = applyNetworkACLRuleCall(ctx, networkACLID, call, ids, options)
+		}
+		if call.Err != nil {
+			for _, skipped := range report.Calls[i+1:] {
+				skipped.Skipped = true
+			}
+			err = fmt.Errorf("%s: %w", call.String(), call.Err)
+			return
+		}
+		call.Applied = true
+		if call.Action != NetworkACLRuleActionDelete {
+			ids[call.Index] = call.RuleID
+		}
+	}
+	return
+}
+
+// listNetworkACLRules returns the normalized rules of a network ACL, in order.
+func listNetworkACLRules(ctx context.Context, networkACLID string, list func(ctx context.Context, networkACLID string, start *string) (interface{}, *core.DetailedResponse, error)) (rules []*NormalizedNetworkACLRule, err error) {
+	listRules := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+		return list(ctx, networkACLID, start)
+	}
+	CollectionObjects(ctx, listRules, "rules", nil)(func(item map[string]interface{}, itemErr error) bool {
+		var rule *NormalizedNetworkACLRule
+		if err = itemErr; err == nil {
+			if rule, err = NormalizeNetworkACLRule(item); err == nil {
+				rules = append(rules, rule)
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// planNetworkACLRules returns the calls turning the current rules into the desired ones, and
+// the IDs of the current rules matching the desired ones (empty for the rules to create).
+func planNetworkACLRules(current []*NormalizedNetworkACLRule, desired []*NormalizedNetworkACLRule, prototypes []interface{}) (report *NetworkACLRuleSyncReport, ids []string) {
+	report = &NetworkACLRuleSyncReport{}
+
+	// Match the desired rules with the current ones: identical rules first, then rules with the
+	// same effect, then rules with the same protocol, which are updated.
+	matches := make([]int, len(desired))
+	for i := range matches {
+		matches[i] = -1
+	}
+	matched := make([]bool, len(current))
+	for _, match := range []func(currentRule, desiredRule *NormalizedNetworkACLRule) bool{
+		func(currentRule, desiredRule *NormalizedNetworkACLRule) bool { return currentRule.Matches(desiredRule) },
+		func(currentRule, desiredRule *NormalizedNetworkACLRule) bool {
+			return currentRule.sameContent(desiredRule)
+		},
+		func(currentRule, desiredRule *NormalizedNetworkACLRule) bool {
+			return currentRule.Protocol == desiredRule.Protocol
+		},
+	} {
+		for i, desiredRule := range desired {
+			for j, currentRule := range current {
+				if matches[i] < 0 && !matched[j] && match(currentRule, desiredRule) {
+					matches[i], matched[j] = j, true
+				}
+			}
+		}
+	}
+
+	ids = make([]string, len(desired))
+	for i, j := range matches {
+		if j >= 0 {
+			ids[i] = current[j].ID
+		}
+	}
+	// The matched rules in the longest sequence already in the desired order stay in place.
+	stays := longestIncreasingSequence(matches)
+	for i := len(desired) - 1; i >= 0; i-- {
+		call := &NetworkACLRuleCall{Desired: desired[i], Prototype: prototypes[i], Index: i, BeforeIndex: i + 1}
+		if i == len(desired)-1 {
+			call.BeforeIndex = -1
+		}
+		if matches[i] < 0 {
+			call.Action = NetworkACLRuleActionCreate
+			call.Move = true
+			report.Calls = append(report.Calls, call)
+			continue
+		}
+		currentRule := current[matches[i]]
+		call.Current = currentRule
+		call.RuleID = currentRule.ID
+		call.Move = !stays[i]
+		if !currentRule.Matches(desired[i]) {
+			call.Patch = currentRule.patchTo(desired[i])
+		}
+		if !call.Move && len(call.Patch) == 0 {
+			report.Unchanged = append(report.Unchanged, currentRule)
+			continue
+		}
+		call.Action = NetworkACLRuleActionUpdate
+		report.Calls = append(report.Calls, call)
+	}
+
+	// The remaining rules are deleted last, once the desired rules are in place.
+	for j, currentRule := range current {
+		if !matched[j] {
+			report.Calls = append(report.Calls, &NetworkACLRuleCall{Action: NetworkACLRuleActionDelete,
+				Current: currentRule, RuleID: currentRule.ID, Index: -1})
+		}
+	}
+	return
+}
+
+// longestIncreasingSequence returns which of the values (ignoring negative ones) are part of
+// a longest strictly increasing subsequence.
+func longestIncreasingSequence(values []int) (members []bool) {
+	members = make([]bool, len(values))
+	// tails[k] is the index of the smallest last value of the increasing subsequences of
+	// length k+1, and previous[i] the index of the value before values[i] in its subsequence.
+	var tails []int
+	previous := make([]int, len(values))
+	for i, value := range values {
+		if value < 0 {
+			continue
+		}
+		low, high := 0, len(tails)
+		for low < high {
+			middle := (low + high) / 2
+			if values[tails[middle]] < value {
+				low = middle + 1
+			} else {
+				high = middle
+			}
+		}
+		previous[i] = -1
+		if low > 0 {
+			previous[i] = tails[low-1]
+		}
+		if low == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[low] = i
+		}
+	}
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = previous[i] {
+			members[i] = true
+		}
+	}
+	return
+}
+
+// applyNetworkACLRuleCall makes one call of a synchronization.
+func applyNetworkACLRuleCall(ctx context.Context, networkACLID string, call *NetworkACLRuleCall, ids []string, options *NetworkACLRuleSyncOptions) error {
+	before := ""
+	if call.BeforeIndex >= 0 {
+		before = ids[call.BeforeIndex]
+	}
+	switch call.Action {
+	case NetworkACLRuleActionCreate:
+		rule, response, err := options.Create(ctx, networkACLID, call.Prototype, before)
+		if err != nil {
+			return NewAPIError(response, err)
+		}
+		created, err := NormalizeNetworkACLRule(rule)
+		if err != nil {
+			return err
+		}
+		call.RuleID = created.ID
+	case NetworkACLRuleActionUpdate:
+		patch := make(map[string]interface{}, len(call.Patch)+1)
+		for key, value := range call.Patch {
+			patch[key] = value
+		}
+		if call.Move {
+			patch["before"] = nil
+			if before != "" {
+				patch["before"] = map[string]interface{}{"id": before}
+			}
+		}
+		_, response, err := options.Update(ctx, networkACLID, call.RuleID, patch)
+		if err != nil {
+			return NewAPIError(response, err)
+		}
+	case NetworkACLRuleActionDelete:
+		response, err := options.Delete(ctx, networkACLID, call.RuleID)
+		if err = NewAPIError(response, err); err != nil && !IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
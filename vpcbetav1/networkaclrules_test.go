@@ -0,0 +1,206 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`SyncNetworkACLRules`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var aclID string
+	var options *vpcbetav1.NetworkACLRuleSyncOptions
+
+	rulesPath := func() string {
+		return fmt.Sprintf("/network_acls/%s/rules", aclID)
+	}
+	// currentRules returns the names of the rules of the network ACL, in order.
+	currentRules := func() (names []string) {
+		result, _, err := invoke(service, core.GET, rulesPath(), nil)
+		Expect(err).To(BeNil())
+		for _, item := range result["rules"].([]interface{}) {
+			rule, err := vpcbetav1.NormalizeNetworkACLRule(item)
+			Expect(err).To(BeNil())
+			names = append(names, rule.Name)
+		}
+		return
+	}
+	calls := func(report *vpcbetav1.NetworkACLRuleSyncReport) (result []string) {
+		for _, call := range report.Calls {
+			result = append(result, call.String())
+		}
+		return
+	}
+	rule := func(name string, action string, direction string, protocol string, extra ...interface{}) map[string]interface{} {
+		result := map[string]interface{}{"action": action, "direction": direction, "protocol": protocol,
+			"source": "0.0.0.0/0", "destination": "0.0.0.0/0"}
+		if name != "" {
+			result["name"] = name
+		}
+		for i := 0; i+1 < len(extra); i += 2 {
+			result[extra[i].(string)] = extra[i+1]
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		vpc, _, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		aclID = vpc["default_network_acl"].(map[string]interface{})["id"].(string)
+		for _, prototype := range []map[string]interface{}{
+			rule("deny-ssh", "deny", "inbound", "tcp", "destination_port_min", 22, "destination_port_max", 22),
+			rule("allow-web", "allow", "inbound", "tcp", "destination_port_min", 80, "destination_port_max", 80),
+			rule("deny-dns", "deny", "outbound", "udp", "destination_port_min", 53, "destination_port_max", 53),
+		} {
+			_, _, err = invoke(service, core.POST, rulesPath(), prototype)
+			Expect(err).To(BeNil())
+		}
+		Expect(currentRules()).To(Equal([]string{"allow-inbound", "allow-outbound", "deny-ssh", "allow-web", "deny-dns"}))
+
+		options = &vpcbetav1.NetworkACLRuleSyncOptions{
+			List: func(ctx context.Context, networkACLID string, start *string) (interface{}, *core.DetailedResponse, error) {
+				path := fmt.Sprintf("/network_acls/%s/rules?limit=2", networkACLID)
+				if start != nil {
+					path += "&start=" + *start
+				}
+				return invoke(service, core.GET, path, nil)
+			},
+			Create: func(ctx context.Context, networkACLID string, prototype interface{}, before string) (interface{}, *core.DetailedResponse, error) {
+				body := map[string]interface{}{}
+				for key, value := range prototype.(map[string]interface{}) {
+					body[key] = value
+				}
+				if before != "" {
+					body["before"] = map[string]interface{}{"id": before}
+				}
+				return invoke(service, core.POST, fmt.Sprintf("/network_acls/%s/rules", networkACLID), body)
+			},
+			Update: func(ctx context.Context, networkACLID string, ruleID string, patch map[string]interface{}) (interface{}, *core.DetailedResponse, error) {
+				return invoke(service, core.PATCH, fmt.Sprintf("/network_acls/%s/rules/%s", networkACLID, ruleID), patch)
+			},
+			Delete: func(ctx context.Context, networkACLID string, ruleID string) (*core.DetailedResponse, error) {
+				_, response, err := invoke(service, core.DELETE, fmt.Sprintf("/network_acls/%s/rules/%s", networkACLID, ruleID), nil)
+				return response, err
+			},
+		}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	desired := func() []interface{} {
+		return []interface{}{
+			rule("allow-web", "allow", "inbound", "tcp", "destination_port_min", 80, "destination_port_max", 80),
+			rule("deny-ssh", "deny", "inbound", "tcp", "destination_port_min", 2222, "destination_port_max", 2222),
+			rule("deny-ping", "deny", "inbound", "icmp", "type", 8),
+			rule("", "allow", "outbound", "all"),
+			rule("allow-inbound", "allow", "inbound", "all"),
+		}
+	}
+
+	It(`Plans the minimal sequence of calls`, func() {
+		options.PlanOnly = true
+		report, err := vpcbetav1.SyncNetworkACLRules(context.Background(), aclID, desired(), options)
+		Expect(err).To(BeNil())
+		Expect(calls(report)).To(Equal([]string{
+			"update allow outbound all 0.0.0.0/0 -> 0.0.0.0/0 before #4",
+			"create deny inbound icmp 0.0.0.0/0 -> 0.0.0.0/0 type 8 before #3",
+			"update deny inbound tcp 0.0.0.0/0 -> 0.0.0.0/0 port 2222-2222 before #2",
+			"update allow inbound tcp 0.0.0.0/0 -> 0.0.0.0/0 port 80-80 before #1",
+			"delete deny outbound udp 0.0.0.0/0 -> 0.0.0.0/0 port 53-53",
+		}))
+		Expect(report.Unchanged).To(HaveLen(1))
+		Expect(report.Calls[2].Patch).To(Equal(map[string]interface{}{
+			"destination_port_min": int64(2222), "destination_port_max": int64(2222),
+		}))
+		Expect(report.Calls[0].Patch).To(BeEmpty())
+		Expect(currentRules()).To(Equal([]string{"allow-inbound", "allow-outbound", "deny-ssh", "allow-web", "deny-dns"}))
+	})
+	It(`Reaches the desired order`, func() {
+		report, err := vpcbetav1.SyncNetworkACLRules(context.Background(), aclID, desired(), options)
+		Expect(err).To(BeNil())
+		for _, call := range report.Calls {
+			Expect(call.Applied).To(BeTrue())
+		}
+		Expect(currentRules()).To(Equal([]string{"allow-web", "deny-ssh", "deny-ping", "allow-outbound", "allow-inbound"}))
+		result, _, err := invoke(service, core.GET, rulesPath(), nil)
+		Expect(err).To(BeNil())
+		Expect(result["rules"].([]interface{})[1]).To(HaveKeyWithValue("destination_port_min", float64(2222)))
+
+		// A synchronized network ACL does not change.
+		report, err = vpcbetav1.SyncNetworkACLRules(context.Background(), aclID, desired(), options)
+		Expect(err).To(BeNil())
+		Expect(report.Calls).To(BeEmpty())
+		Expect(report.Unchanged).To(HaveLen(5))
+
+		// Moving a rule to the end is a single call.
+		reordered := desired()
+		reordered = append(reordered[1:], reordered[0])
+		report, err = vpcbetav1.SyncNetworkACLRules(context.Background(), aclID, reordered, options)
+		Expect(err).To(BeNil())
+		Expect(calls(report)).To(Equal([]string{"update allow inbound tcp 0.0.0.0/0 -> 0.0.0.0/0 port 80-80 at the end"}))
+		Expect(currentRules()).To(Equal([]string{"deny-ssh", "deny-ping", "allow-outbound", "allow-inbound", "allow-web"}))
+	})
+	It(`Accepts addresses as sources and destinations`, func() {
+		normalized, err := vpcbetav1.NormalizeNetworkACLRule(rule("", "deny", "inbound", "all", "source", "10.0.0.5", "destination", "fe80::1"))
+		Expect(err).To(BeNil())
+		Expect(normalized.Source).To(Equal("10.0.0.5/32"))
+		Expect(normalized.Destination).To(Equal("fe80::1/128"))
+		_, err = vpcbetav1.NormalizeNetworkACLRule(rule("", "deny", "inbound", "all", "source", "10.0.0.256"))
+		Expect(err).ToNot(BeNil())
+
+		_, _, err = invoke(service, core.POST, rulesPath(), rule("deny-host", "deny", "inbound", "all", "source", "10.0.0.5"))
+		Expect(err).To(BeNil())
+		synchronized := []interface{}{
+			rule("allow-inbound", "allow", "inbound", "all"),
+			rule("allow-outbound", "allow", "outbound", "all"),
+			rule("deny-ssh", "deny", "inbound", "tcp", "destination_port_min", 22, "destination_port_max", 22),
+			rule("allow-web", "allow", "inbound", "tcp", "destination_port_min", 80, "destination_port_max", 80),
+			rule("deny-dns", "deny", "outbound", "udp", "destination_port_min", 53, "destination_port_max", 53),
+			rule("deny-host", "deny", "inbound", "all", "source", "10.0.0.5/32"),
+		}
+		report, err := vpcbetav1.SyncNetworkACLRules(context.Background(), aclID, synchronized, options)
+		Expect(err).To(BeNil())
+		Expect(report.Calls).To(BeEmpty())
+	})
+	It(`Stops at the first failure`, func() {
+		options.Create = func(ctx context.Context, networkACLID string, prototype interface{}, before string) (interface{}, *core.DetailedResponse, error) {
+			return invoke(service, core.POST, fmt.Sprintf("/network_acls/%s/rules", networkACLID), map[string]interface{}{})
+		}
+		report, err := vpcbetav1.SyncNetworkACLRules(context.Background(), aclID, desired(), options)
+		Expect(err).ToNot(BeNil())
+		Expect(vpcbetav1.IsValidationError(err)).To(BeTrue())
+		Expect(err.Error()).To(HavePrefix("create deny inbound icmp"))
+		Expect(report.Calls[0].Applied).To(BeTrue())
+		Expect(report.Calls[1].Err).ToNot(BeNil())
+		Expect(report.Calls[2].Skipped).To(BeTrue())
+		// The stale rule is not deleted before the desired rules are in place.
+		Expect(report.Calls[4].Action).To(Equal(vpcbetav1.NetworkACLRuleActionDelete))
+		Expect(report.Calls[4].Skipped).To(BeTrue())
+		Expect(currentRules()).To(ContainElement("deny-dns"))
+	})
+})
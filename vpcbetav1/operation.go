@@ -0,0 +1,146 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"net/http"
+	"strings"
+)
+
+// OperationID returns the ID of the API operation (for example `create_instance` or
+// `list_vpc_address_prefixes`) of a request, derived from its method and path following the
+// naming conventions of the VPC API:
+//
+//	GET    /instances                      list_instances
+//	POST   /instances                      create_instance
+//	GET    /instances/{id}                 get_instance
+//	PATCH  /instances/{id}                 update_instance
+//	DELETE /instances/{id}                 delete_instance
+//	POST   /instances/{id}/actions         create_instance_action
+//	GET    /vpcs/{vpc_id}/address_prefixes list_vpc_address_prefixes
+//	PUT    /subnets/{id}/network_acl       replace_subnet_network_acl
+//	GET    /instance/profiles              list_instance_profiles
+//	GET    /instance/profiles/{name}       get_instance_profile
+//
+// The path may include the service URL prefix (up to `/v1`). A few operations of the API
+// deviate from these conventions, so rules keyed by operation ID should be checked against
+// this function.
+func OperationID(method string, path string) string {
+	segments := PathSegments(path)
+	if len(segments) == 0 {
+		return ""
+	}
+
+	// Segments alternate between collections and identifiers; the last collection names
+	// the operation and the preceding ones qualify it.
+	var words []string
+	for i := 0; i < len(segments)-1; i += 2 {
+		if i+2 < len(segments) {
+			words = append(words, singular(segments[i]))
+		}
+	}
+
+	last := segments[len(segments)-1]
+	if len(segments)%2 == 0 {
+		// An item of a collection.
+		name := singular(segments[len(segments)-2])
+		switch method {
+		case http.MethodGet:
+			return joinOperationID("get", words, name)
+		case http.MethodPatch:
+			return joinOperationID("update", words, name)
+		case http.MethodPut:
+			return joinOperationID("replace", words, name)
+		case http.MethodDelete:
+			return joinOperationID("delete", words, name)
+		}
+		return joinOperationID(strings.ToLower(method), words, name)
+	}
+
+	if !strings.HasSuffix(last, "s") {
+		// A singleton sub-resource, such as the network ACL of a subnet.
+		switch method {
+		case http.MethodGet:
+			return joinOperationID("get", words, last)
+		case http.MethodPost:
+			return joinOperationID("create", words, last)
+		case http.MethodPut:
+			return joinOperationID("replace", words, last)
+		case http.MethodDelete:
+			return joinOperationID("delete", words, last)
+		}
+		return joinOperationID(strings.ToLower(method), words, last)
+	}
+
+	switch method {
+	case http.MethodGet:
+		return joinOperationID("list", words, last)
+	case http.MethodPost:
+		return joinOperationID("create", words, singular(last))
+	}
+	return joinOperationID(strings.ToLower(method), words, last)
+}
+
+// PathSegments returns the segments of a request path, without the service URL prefix (up to
+// `/v1`), which alternate between collections and identifiers: collections with a singular
+// prefix are returned as single segments, for example `/v1/instance/profiles/bx2-2x8` as
+// `instance_profiles` and `bx2-2x8`. A path with an even number of segments addresses the
+// resource identified by its last segment.
+func PathSegments(path string) []string {
+	if i := strings.LastIndex(path, "/v1/"); i >= 0 {
+		path = path[i+len("/v1"):]
+	}
+	return collectionSegments(strings.FieldsFunc(path, func(r rune) bool { return r == '/' }))
+}
+
+// collectionSegments joins the collections with a singular prefix, such as `instance/profiles`
+// or `dedicated_host/groups`, into single segments (`instance_profiles`), so that segments
+// alternate between collections and identifiers.
+func collectionSegments(segments []string) []string {
+	var joined []string
+	for i := 0; i < len(segments); i++ {
+		if len(joined)%2 == 0 && i+1 < len(segments) && !strings.HasSuffix(segments[i], "s") {
+			joined = append(joined, segments[i]+"_"+segments[i+1])
+			i++
+			continue
+		}
+		joined = append(joined, segments[i])
+	}
+	return joined
+}
+
+// operationIDOf returns the operation ID of a request.
+func operationIDOf(req *http.Request) string {
+	return OperationID(req.Method, req.URL.Path)
+}
+
+// joinOperationID joins a verb, the qualifying words and a name into an operation ID.
+func joinOperationID(verb string, words []string, name string) string {
+	return strings.Join(append(append([]string{verb}, words...), name), "_")
+}
+
+// singular returns the singular of a collection name such as `address_prefixes`.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	}
+	return strings.TrimSuffix(name, "s")
+}
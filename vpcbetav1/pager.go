@@ -0,0 +1,266 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// PageFetchFunc retrieves one page of a collection, starting at the given `start` token
+// (nil for the first page). It returns the items of the page and the `next.href` URL of
+// the collection, or nil if this is the last page.
+// It is usually a thin wrapper around one of the generated List...WithContext operations.
+type PageFetchFunc[T any] func(ctx context.Context, start *string) (items []T, next *string, err error)
+
+// CollectionListFunc retrieves one page of a collection with the given `start` token (nil
+// for the first page) and page size (nil for the service default).
+// It is usually a thin wrapper around one of the generated List...WithContext operations,
+// setting the Start and Limit fields of the operation options and returning its results.
+type CollectionListFunc func(ctx context.Context, start *string, limit *int64) (collection interface{}, response *core.DetailedResponse, err error)
+
+// PagerOptions : The PagerOptions struct configures NewCollectionPager.
+type PagerOptions struct {
+	// The number of resources to return on a page (1 to 100).
+	Limit *int64
+}
+
+// SetLimit : Allow user to set Limit
+func (_options *PagerOptions) SetLimit(limit int64) *PagerOptions {
+	_options.Limit = core.Int64Ptr(limit)
+	return _options
+}
+
+// Pager can be used to simplify the use of a paginated List operation.
+type Pager[T any] struct {
+	hasNext     bool
+	fetch       PageFetchFunc[T]
+	pageContext struct {
+		next *string
+	}
+}
+
+// NewPager returns a new Pager instance that retrieves pages with the given fetch function.
+func NewPager[T any](fetch PageFetchFunc[T]) (pager *Pager[T], err error) {
+	if fetch == nil {
+		err = fmt.Errorf("fetch function cannot be nil")
+		return
+	}
+
+	pager = &Pager[T]{
+		hasNext: true,
+		fetch:   fetch,
+	}
+	return
+}
+
+// NewCollectionPager returns a new Pager instance for any paginated List operation whose
+// result is one of the ...Collection models, i.e. a struct with a `Next` link holding an
+// `Href` and a slice of T holding the resources of the page.
+func NewCollectionPager[T any](list CollectionListFunc, options *PagerOptions) (pager *Pager[T], err error) {
+	if list == nil {
+		err = fmt.Errorf("list function cannot be nil")
+		return
+	}
+	limit, err := pagerLimit(options)
+	if err != nil {
+		return
+	}
+
+	return NewPager(func(ctx context.Context, start *string) (items []T, next *string, err error) {
+		collection, _, err := list(ctx, start, limit)
+		if err != nil {
+			return
+		}
+		return CollectionPage[T](collection)
+	})
+}
+
+// NewCollectionObjectPager returns a new Pager instance for any paginated List operation,
+// whose resources are the JSON objects of the given property of the collection (see
+// CollectionObjectPage). It suits the collections whose resources are not of a single type,
+// such as the NetworkACLRuleCollection model. Errors of the List operation are returned as
+// described by NewAPIError.
+func NewCollectionObjectPager(list CollectionListFunc, property string, options *PagerOptions) (pager *Pager[map[string]interface{}], err error) {
+	if list == nil {
+		err = fmt.Errorf("list function cannot be nil")
+		return
+	}
+	limit, err := pagerLimit(options)
+	if err != nil {
+		return
+	}
+
+	return NewPager(func(ctx context.Context, start *string) (items []map[string]interface{}, next *string, err error) {
+		collection, response, err := list(ctx, start, limit)
+		if err != nil {
+			err = NewAPIError(response, err)
+			return
+		}
+		return CollectionObjectPage(collection, property)
+	})
+}
+
+// CollectionObjectPage extracts the resources of the given JSON property, as JSON objects, and
+// the `next.href` URL from a collection: one of the ...Collection models, or its JSON object.
+func CollectionObjectPage(collection interface{}, property string) (items []map[string]interface{}, next *string, err error) {
+	object, err := ToJSONObject(collection)
+	if err != nil {
+		return
+	}
+	values, ok := object[property].([]interface{})
+	if !ok && object[property] != nil {
+		err = fmt.Errorf("collection property '%s' is not an array", property)
+		return
+	}
+	for _, value := range values {
+		item, ok := value.(map[string]interface{})
+		if !ok {
+			err = fmt.Errorf("collection property '%s' holds a value that is not an object", property)
+			return nil, nil, err
+		}
+		items = append(items, item)
+	}
+	if nextLink, ok := object["next"].(map[string]interface{}); ok {
+		if href, ok := nextLink["href"].(string); ok && href != "" {
+			next = &href
+		}
+	}
+	return
+}
+
+// pagerLimit returns the validated page size of the pager options.
+func pagerLimit(options *PagerOptions) (limit *int64, err error) {
+	if options != nil && options.Limit != nil {
+		if *options.Limit < 1 || *options.Limit > 100 {
+			err = fmt.Errorf("the 'options.Limit' field must be between 1 and 100")
+			return
+		}
+		limit = core.Int64Ptr(*options.Limit)
+	}
+	return
+}
+
+// CollectionPage extracts the resources and the `next.href` URL from one of the ...Collection
+// models (or a pointer to it).
+func CollectionPage[T any](collection interface{}) (items []T, next *string, err error) {
+	value := reflect.ValueOf(collection)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			err = fmt.Errorf("collection cannot be nil")
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		err = fmt.Errorf("collection must be a struct, not %s", value.Kind())
+		return
+	}
+
+	itemsType := reflect.TypeOf(items)
+	found := false
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Type() != itemsType || !field.CanInterface() {
+			continue
+		}
+		if found {
+			err = fmt.Errorf("collection %s has more than one field of type %s", value.Type(), itemsType)
+			return
+		}
+		items = field.Interface().([]T)
+		found = true
+	}
+	if !found {
+		err = fmt.Errorf("collection %s has no field of type %s", value.Type(), itemsType)
+		return
+	}
+
+	nextLink := value.FieldByName("Next")
+	if nextLink.IsValid() && nextLink.Kind() == reflect.Ptr && !nextLink.IsNil() {
+		href := nextLink.Elem().FieldByName("Href")
+		if href.IsValid() && href.Type() == reflect.TypeOf(next) {
+			next = href.Interface().(*string)
+		}
+	}
+	return
+}
+
+// HasNext returns true if there are potentially more results to be retrieved.
+func (pager *Pager[T]) HasNext() bool {
+	return pager.hasNext
+}
+
+// GetNextWithContext returns the next page of results.
+// The context is checked before the page is requested, so a cancelled context stops the
+// pager without issuing another request.
+func (pager *Pager[T]) GetNextWithContext(ctx context.Context) (page []T, err error) {
+	if !pager.HasNext() {
+		return nil, fmt.Errorf("no more results available")
+	}
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	items, nextHref, err := pager.fetch(ctx, pager.pageContext.next)
+	if err != nil {
+		return
+	}
+
+	var next *string
+	if nextHref != nil {
+		var start *string
+		start, err = core.GetQueryParam(nextHref, "start")
+		if err != nil {
+			err = fmt.Errorf("error retrieving 'start' query parameter from URL '%s': %s", *nextHref, err.Error())
+			return
+		}
+		next = start
+	}
+	pager.pageContext.next = next
+	pager.hasNext = (pager.pageContext.next != nil)
+	page = items
+
+	return
+}
+
+// GetAllWithContext returns all results by invoking GetNextWithContext() repeatedly
+// until all pages of results have been retrieved or the context is done.
+func (pager *Pager[T]) GetAllWithContext(ctx context.Context) (allItems []T, err error) {
+	for pager.HasNext() {
+		var nextPage []T
+		nextPage, err = pager.GetNextWithContext(ctx)
+		if err != nil {
+			return
+		}
+		allItems = append(allItems, nextPage...)
+	}
+	return
+}
+
+// GetNext invokes GetNextWithContext() using context.Background() as the Context parameter.
+func (pager *Pager[T]) GetNext() (page []T, err error) {
+	return pager.GetNextWithContext(context.Background())
+}
+
+// GetAll invokes GetAllWithContext() using context.Background() as the Context parameter.
+func (pager *Pager[T]) GetAll() (allItems []T, err error) {
+	return pager.GetAllWithContext(context.Background())
+}
@@ -0,0 +1,265 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testCollection mirrors the shape of the generated ...Collection models.
+type testCollection struct {
+	Next *struct {
+		Href *string `json:"href"`
+	} `json:"next,omitempty"`
+	Items []string `json:"items"`
+}
+
+// newTestService returns a BaseService pointed at the given test server.
+func newTestService(url string) *core.BaseService {
+	service, err := core.NewBaseService(&core.ServiceOptions{
+		URL:           url,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	Expect(err).To(BeNil())
+	return service
+}
+
+// listTestItems issues a paginated GET request the way the generated List operations do.
+func listTestItems(ctx context.Context, service *core.BaseService, start *string) ([]string, *string, error) {
+	builder := core.NewRequestBuilder(core.GET)
+	builder = builder.WithContext(ctx)
+	_, err := builder.ResolveRequestURL(service.Options.URL, `/items`, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if start != nil {
+		builder.AddQuery("start", *start)
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	var result testCollection
+	_, err = service.Request(request, &result)
+	if err != nil {
+		return nil, nil, err
+	}
+	var next *string
+	if result.Next != nil {
+		next = result.Next.Href
+	}
+	return result.Items, next, nil
+}
+
+var _ = Describe(`Pager`, func() {
+	pageHandler := func(requests *int) http.HandlerFunc {
+		return func(res http.ResponseWriter, req *http.Request) {
+			*requests++
+			res.Header().Set("Content-type", "application/json")
+			switch req.URL.Query().Get("start") {
+			case "":
+				fmt.Fprintf(res, `{"items":["a","b"],"next":{"href":"https://myhost.com/somePath?start=p2"}}`)
+			case "p2":
+				fmt.Fprintf(res, `{"items":["c","d"],"next":{"href":"https://myhost.com/somePath?start=p3"}}`)
+			default:
+				fmt.Fprintf(res, `{"items":["e"]}`)
+			}
+		}
+	}
+
+	It(`Invoke GetNext successfully`, func() {
+		requests := 0
+		testServer := httptest.NewServer(pageHandler(&requests))
+		defer testServer.Close()
+		service := newTestService(testServer.URL)
+
+		pager, err := vpcbetav1.NewPager(func(ctx context.Context, start *string) ([]string, *string, error) {
+			return listTestItems(ctx, service, start)
+		})
+		Expect(err).To(BeNil())
+		Expect(pager.HasNext()).To(BeTrue())
+
+		var allResults []string
+		for pager.HasNext() {
+			nextPage, err := pager.GetNext()
+			Expect(err).To(BeNil())
+			allResults = append(allResults, nextPage...)
+		}
+		Expect(allResults).To(Equal([]string{"a", "b", "c", "d", "e"}))
+		Expect(requests).To(Equal(3))
+
+		_, err = pager.GetNext()
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke GetAll successfully`, func() {
+		requests := 0
+		testServer := httptest.NewServer(pageHandler(&requests))
+		defer testServer.Close()
+		service := newTestService(testServer.URL)
+
+		pager, err := vpcbetav1.NewPager(func(ctx context.Context, start *string) ([]string, *string, error) {
+			return listTestItems(ctx, service, start)
+		})
+		Expect(err).To(BeNil())
+		allResults, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(allResults).To(HaveLen(5))
+	})
+	It(`Stops between pages when the context is cancelled`, func() {
+		requests := 0
+		testServer := httptest.NewServer(pageHandler(&requests))
+		defer testServer.Close()
+		service := newTestService(testServer.URL)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		pager, err := vpcbetav1.NewPager(func(ctx context.Context, start *string) ([]string, *string, error) {
+			items, next, err := listTestItems(ctx, service, start)
+			cancel()
+			return items, next, err
+		})
+		Expect(err).To(BeNil())
+		allResults, err := pager.GetAllWithContext(ctx)
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		Expect(allResults).To(Equal([]string{"a", "b"}))
+		Expect(requests).To(Equal(1))
+	})
+	It(`Aborts an in-flight request when the context is cancelled`, func() {
+		release := make(chan struct{})
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			select {
+			case <-release:
+			case <-req.Context().Done():
+			}
+		}))
+		defer testServer.Close()
+		defer close(release)
+		service := newTestService(testServer.URL)
+
+		pager, err := vpcbetav1.NewPager(func(ctx context.Context, start *string) ([]string, *string, error) {
+			return listTestItems(ctx, service, start)
+		})
+		Expect(err).To(BeNil())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		begin := time.Now()
+		_, err = pager.GetNextWithContext(ctx)
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		Expect(time.Since(begin)).To(BeNumerically("<", 5*time.Second))
+		Expect(pager.HasNext()).To(BeTrue())
+	})
+	It(`Invoke GetNext with error`, func() {
+		pager, err := vpcbetav1.NewPager(func(ctx context.Context, start *string) ([]string, *string, error) {
+			return nil, core.StringPtr("%zz"), nil
+		})
+		Expect(err).To(BeNil())
+		_, err = pager.GetNext()
+		Expect(err).ToNot(BeNil())
+
+		_, err = vpcbetav1.NewPager[string](nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke NewCollectionPager successfully`, func() {
+		var limits []int64
+		pager, err := vpcbetav1.NewCollectionPager[string](func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			limits = append(limits, *limit)
+			collection := &testCollection{Items: []string{"a", "b"}}
+			if start == nil {
+				collection.Next = &struct {
+					Href *string `json:"href"`
+				}{Href: core.StringPtr("https://myhost.com/somePath?limit=2&start=p2")}
+			} else {
+				Expect(*start).To(Equal("p2"))
+				collection.Items = []string{"c"}
+			}
+			return collection, &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+		}, new(vpcbetav1.PagerOptions).SetLimit(2))
+		Expect(err).To(BeNil())
+		allResults, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(allResults).To(Equal([]string{"a", "b", "c"}))
+		Expect(limits).To(Equal([]int64{2, 2}))
+	})
+	It(`Invoke NewCollectionPager with error`, func() {
+		list := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			return &testCollection{}, nil, nil
+		}
+		_, err := vpcbetav1.NewCollectionPager[string](nil, nil)
+		Expect(err).ToNot(BeNil())
+		_, err = vpcbetav1.NewCollectionPager[string](list, new(vpcbetav1.PagerOptions).SetLimit(101))
+		Expect(err).ToNot(BeNil())
+
+		pager, err := vpcbetav1.NewCollectionPager[int](list, nil)
+		Expect(err).To(BeNil())
+		_, err = pager.GetNext()
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Invoke NewCollectionObjectPager successfully`, func() {
+		pager, err := vpcbetav1.NewCollectionObjectPager(func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			if start == nil {
+				return map[string]interface{}{
+					"rules": []interface{}{map[string]interface{}{"id": "a", "protocol": "tcp"}},
+					"next":  map[string]interface{}{"href": "https://myhost.com/somePath?start=p2"},
+				}, &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+			}
+			Expect(*start).To(Equal("p2"))
+			return map[string]interface{}{
+				"rules": []interface{}{map[string]interface{}{"id": "b", "protocol": "all"}},
+			}, &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+		}, "rules", nil)
+		Expect(err).To(BeNil())
+		allResults, err := pager.GetAll()
+		Expect(err).To(BeNil())
+		Expect(allResults).To(Equal([]map[string]interface{}{{"id": "a", "protocol": "tcp"}, {"id": "b", "protocol": "all"}}))
+	})
+	It(`Invoke NewCollectionObjectPager with error`, func() {
+		_, err := vpcbetav1.NewCollectionObjectPager(nil, "items", nil)
+		Expect(err).ToNot(BeNil())
+
+		pager, err := vpcbetav1.NewCollectionObjectPager(func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			return nil, &core.DetailedResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("Not Found")
+		}, "items", nil)
+		Expect(err).To(BeNil())
+		_, err = pager.GetNext()
+		Expect(vpcbetav1.IsNotFound(err)).To(BeTrue())
+
+		_, _, err = vpcbetav1.CollectionObjectPage(&testCollection{Items: []string{"a"}}, "items")
+		Expect(err).ToNot(BeNil())
+		items, next, err := vpcbetav1.CollectionObjectPage(&testCollection{}, "rules")
+		Expect(err).To(BeNil())
+		Expect(items).To(BeEmpty())
+		Expect(next).To(BeNil())
+	})
+	It(`Invoke CollectionPage with error`, func() {
+		_, _, err := vpcbetav1.CollectionPage[string](nil)
+		Expect(err).ToNot(BeNil())
+		_, _, err = vpcbetav1.CollectionPage[string]((*testCollection)(nil))
+		Expect(err).ToNot(BeNil())
+		_, _, err = vpcbetav1.CollectionPage[string]("not a collection")
+		Expect(err).ToNot(BeNil())
+	})
+})
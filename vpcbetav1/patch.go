@@ -0,0 +1,97 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DiffPatch returns the minimal JSON merge patch (RFC 7386) that turns current into desired.
+//
+// current and desired are two states of the same resource, usually the model returned by a
+// Get... operation and a modified copy of it. They are compared through their JSON
+// representation: properties whose value changed are set to the desired value, nested
+// objects are compared recursively, arrays are replaced as a whole, and properties present
+// in current but absent (nil) in desired are set to null, which clears them on the server
+// (e.g. a subnet's `public_gateway`).
+//
+// The result can be used directly as the ...Patch map of the Update... options; it is empty
+// if there is nothing to change.
+func DiffPatch(current interface{}, desired interface{}) (patch map[string]interface{}, err error) {
+	currentMap, err := ToJSONObject(current)
+	if err != nil {
+		err = fmt.Errorf("error converting current state: %s", err.Error())
+		return
+	}
+	desiredMap, err := ToJSONObject(desired)
+	if err != nil {
+		err = fmt.Errorf("error converting desired state: %s", err.Error())
+		return
+	}
+	patch = diffObjects(currentMap, desiredMap)
+	return
+}
+
+// diffObjects returns the merge patch that turns current into desired.
+func diffObjects(current map[string]interface{}, desired map[string]interface{}) map[string]interface{} {
+	patch := make(map[string]interface{})
+	for key, currentValue := range current {
+		if _, ok := desired[key]; !ok && currentValue != nil {
+			patch[key] = nil
+		}
+	}
+	for key, desiredValue := range desired {
+		currentValue, ok := current[key]
+		if !ok {
+			if desiredValue != nil {
+				patch[key] = desiredValue
+			}
+			continue
+		}
+
+		currentObject, currentIsObject := currentValue.(map[string]interface{})
+		desiredObject, desiredIsObject := desiredValue.(map[string]interface{})
+		if currentIsObject && desiredIsObject {
+			if nested := diffObjects(currentObject, desiredObject); len(nested) > 0 {
+				patch[key] = nested
+			}
+		} else if !reflect.DeepEqual(currentValue, desiredValue) {
+			patch[key] = desiredValue
+		}
+	}
+	return patch
+}
+
+// ToJSONObject converts a model into its JSON representation as a generic object.
+// A nil model is converted into an empty object.
+func ToJSONObject(model interface{}) (object map[string]interface{}, err error) {
+	object = make(map[string]interface{})
+	if model == nil {
+		return
+	}
+	buf, err := json.Marshal(model)
+	if err != nil {
+		return
+	}
+	if string(buf) == "null" {
+		return
+	}
+	err = json.Unmarshal(buf, &object)
+	return
+}
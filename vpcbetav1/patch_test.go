@@ -0,0 +1,109 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testReference and testSubnet mirror the shape of the generated models.
+type testReference struct {
+	ID   *string `json:"id" validate:"required"`
+	Name *string `json:"name,omitempty"`
+}
+
+type testSubnet struct {
+	ID            *string          `json:"id" validate:"required"`
+	Name          *string          `json:"name" validate:"required"`
+	NetworkACL    *testReference   `json:"network_acl" validate:"required"`
+	PublicGateway *testReference   `json:"public_gateway,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Routes        []*testReference `json:"routes,omitempty"`
+}
+
+var _ = Describe(`DiffPatch`, func() {
+	newSubnet := func() *testSubnet {
+		return &testSubnet{
+			ID:            core.StringPtr("subnet-1"),
+			Name:          core.StringPtr("my-subnet"),
+			NetworkACL:    &testReference{ID: core.StringPtr("acl-1"), Name: core.StringPtr("my-acl")},
+			PublicGateway: &testReference{ID: core.StringPtr("pgw-1")},
+			Tags:          []string{"a", "b"},
+		}
+	}
+
+	It(`Returns an empty patch for identical states`, func() {
+		patch, err := vpcbetav1.DiffPatch(newSubnet(), newSubnet())
+		Expect(err).To(BeNil())
+		Expect(patch).To(BeEmpty())
+	})
+	It(`Returns the changed properties only`, func() {
+		desired := newSubnet()
+		desired.Name = core.StringPtr("my-subnet-updated")
+		desired.NetworkACL.ID = core.StringPtr("acl-2")
+		desired.Tags = []string{"a"}
+
+		patch, err := vpcbetav1.DiffPatch(newSubnet(), desired)
+		Expect(err).To(BeNil())
+		Expect(patch).To(Equal(map[string]interface{}{
+			"name":        "my-subnet-updated",
+			"network_acl": map[string]interface{}{"id": "acl-2"},
+			"tags":        []interface{}{"a"},
+		}))
+	})
+	It(`Sets cleared properties to null`, func() {
+		desired := newSubnet()
+		desired.PublicGateway = nil
+		desired.NetworkACL.Name = nil
+
+		patch, err := vpcbetav1.DiffPatch(newSubnet(), desired)
+		Expect(err).To(BeNil())
+		Expect(patch).To(HaveLen(2))
+		Expect(patch).To(HaveKeyWithValue("public_gateway", BeNil()))
+		Expect(patch).To(HaveKeyWithValue("network_acl", map[string]interface{}{"name": nil}))
+	})
+	It(`Adds new properties`, func() {
+		current := newSubnet()
+		current.PublicGateway = nil
+		patch, err := vpcbetav1.DiffPatch(current, newSubnet())
+		Expect(err).To(BeNil())
+		Expect(patch).To(Equal(map[string]interface{}{
+			"public_gateway": map[string]interface{}{"id": "pgw-1"},
+		}))
+	})
+	It(`Accepts generic maps with explicit nulls`, func() {
+		patch, err := vpcbetav1.DiffPatch(
+			map[string]interface{}{"advertise": true, "name": "route"},
+			map[string]interface{}{"advertise": nil, "name": "route"})
+		Expect(err).To(BeNil())
+		Expect(patch).To(HaveLen(1))
+		Expect(patch).To(HaveKeyWithValue("advertise", BeNil()))
+
+		patch, err = vpcbetav1.DiffPatch(nil, map[string]interface{}{"name": "route"})
+		Expect(err).To(BeNil())
+		Expect(patch).To(Equal(map[string]interface{}{"name": "route"}))
+	})
+	It(`Invoke DiffPatch with error`, func() {
+		_, err := vpcbetav1.DiffPatch([]string{"not", "an", "object"}, newSubnet())
+		Expect(err).ToNot(BeNil())
+		_, err = vpcbetav1.DiffPatch(newSubnet(), make(chan int))
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,376 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitRetries is the number of times a request answered with 429 Too Many
+// Requests is retried, unless configured otherwise.
+const DefaultRateLimitRetries = 3
+
+// RateLimit : A token-bucket budget.
+type RateLimit struct {
+	// The number of requests per second.
+	Rate float64
+
+	// The number of requests that can be sent at once. If not set, 1 is used.
+	Burst int
+}
+
+// RateLimiterOptions : The RateLimiterOptions struct configures a RateLimiter.
+type RateLimiterOptions struct {
+	// The budget shared by all requests. If nil, only the per-operation budgets apply.
+	Global *RateLimit
+
+	// The budgets of individual operations, keyed by operation ID (see OperationID), such as
+	// `list_instances`. A request must fit in both the global and its operation's budget.
+	Operations map[string]*RateLimit
+
+	// The number of times a request answered with 429 Too Many Requests is retried. If not
+	// set, DefaultRateLimitRetries is used; a negative value disables retries.
+	MaxRetries int
+
+	// The minimum and maximum delays between retries of requests answered without a
+	// Retry-After header. If not set, 1 second and 30 seconds are used.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// RateLimitMetrics : The metrics of a RateLimiter.
+type RateLimitMetrics struct {
+	// The number of requests that obtained a token.
+	Requests int64
+
+	// The number of requests that had to wait for a token or a Retry-After delay.
+	Waits int64
+
+	// The total time spent waiting.
+	WaitTime time.Duration
+
+	// The number of 429 Too Many Requests responses received.
+	Throttled int64
+
+	// The number of requests retried after a 429 Too Many Requests response.
+	Retries int64
+}
+
+// add adds a wait to the metrics.
+func (metrics *RateLimitMetrics) add(wait time.Duration) {
+	metrics.Requests++
+	if wait > 0 {
+		metrics.Waits++
+		metrics.WaitTime += wait
+	}
+}
+
+// tokenBucket is a token bucket that lends tokens ahead of time: a request taking a token
+// from an empty bucket waits until the token would have been refilled.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket.
+func newTokenBucket(limit *RateLimit, now time.Time) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: now}
+}
+
+// reserve takes a token and returns the time to wait before using it.
+func (bucket *tokenBucket) reserve(now time.Time) time.Duration {
+	if bucket.rate <= 0 {
+		return 0
+	}
+	bucket.tokens += now.Sub(bucket.last).Seconds() * bucket.rate
+	if bucket.tokens > bucket.burst {
+		bucket.tokens = bucket.burst
+	}
+	bucket.last = now
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// cancel gives back a token that was not used.
+func (bucket *tokenBucket) cancel() {
+	bucket.tokens++
+}
+
+// RateLimiter : A client-side rate limiter shared by concurrent requests. When a request is
+// answered with 429 Too Many Requests, all requests wait until the delay given by the
+// Retry-After header has elapsed.
+type RateLimiter struct {
+	options      RateLimiterOptions
+	mutex        sync.Mutex
+	global       *tokenBucket
+	operations   map[string]*tokenBucket
+	blockedUntil time.Time
+	metrics      RateLimitMetrics
+	perOperation map[string]*RateLimitMetrics
+}
+
+// NewRateLimiter returns a new RateLimiter.
+func NewRateLimiter(options *RateLimiterOptions) (limiter *RateLimiter, err error) {
+	if options == nil {
+		options = &RateLimiterOptions{}
+	}
+	limits := map[string]*RateLimit{"": options.Global}
+	for operationID, limit := range options.Operations {
+		limits[operationID] = limit
+	}
+	for operationID, limit := range limits {
+		if limit != nil && limit.Rate <= 0 {
+			err = fmt.Errorf("the rate of the budget of '%s' must be positive", operationID)
+			return
+		}
+	}
+
+	now := time.Now()
+	limiter = &RateLimiter{
+		options:      *options,
+		operations:   make(map[string]*tokenBucket),
+		perOperation: make(map[string]*RateLimitMetrics),
+	}
+	if options.Global != nil {
+		limiter.global = newTokenBucket(options.Global, now)
+	}
+	for operationID, limit := range options.Operations {
+		if limit != nil {
+			limiter.operations[operationID] = newTokenBucket(limit, now)
+		}
+	}
+	return
+}
+
+// Wait blocks until a request of the given operation fits in the budgets, or the context is
+// done.
+func (limiter *RateLimiter) Wait(ctx context.Context, operationID string) error {
+	now := time.Now()
+	limiter.mutex.Lock()
+	var delay time.Duration
+	var reserved []*tokenBucket
+	for _, bucket := range []*tokenBucket{limiter.global, limiter.operations[operationID]} {
+		if bucket == nil {
+			continue
+		}
+		reserved = append(reserved, bucket)
+		if d := bucket.reserve(now); d > delay {
+			delay = d
+		}
+	}
+	if d := limiter.blockedUntil.Sub(now); d > delay {
+		delay = d
+	}
+	limiter.mutex.Unlock()
+
+	if delay > 0 {
+		if err := sleepContext(ctx, delay); err != nil {
+			limiter.mutex.Lock()
+			for _, bucket := range reserved {
+				bucket.cancel()
+			}
+			limiter.mutex.Unlock()
+			return err
+		}
+	}
+
+	limiter.mutex.Lock()
+	limiter.metrics.add(delay)
+	limiter.operationMetrics(operationID).add(delay)
+	limiter.mutex.Unlock()
+	return nil
+}
+
+// Metrics returns the metrics of all requests.
+func (limiter *RateLimiter) Metrics() RateLimitMetrics {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	return limiter.metrics
+}
+
+// OperationMetrics returns the metrics of the requests of an operation.
+func (limiter *RateLimiter) OperationMetrics(operationID string) RateLimitMetrics {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	return *limiter.operationMetrics(operationID)
+}
+
+// operationMetrics returns the metrics of an operation; the mutex must be held.
+func (limiter *RateLimiter) operationMetrics(operationID string) *RateLimitMetrics {
+	metrics := limiter.perOperation[operationID]
+	if metrics == nil {
+		metrics = &RateLimitMetrics{}
+		limiter.perOperation[operationID] = metrics
+	}
+	return metrics
+}
+
+// throttled records a 429 response and blocks all requests for the given delay.
+func (limiter *RateLimiter) throttled(operationID string, delay time.Duration, retry bool) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	for _, metrics := range []*RateLimitMetrics{&limiter.metrics, limiter.operationMetrics(operationID)} {
+		metrics.Throttled++
+		if retry {
+			metrics.Retries++
+		}
+	}
+	if until := time.Now().Add(delay); retry && until.After(limiter.blockedUntil) {
+		limiter.blockedUntil = until
+	}
+}
+
+// RateLimitTransport : An http.RoundTripper that sends requests within the budgets of a
+// RateLimiter and retries requests answered with 429 Too Many Requests, after the delay given
+// by their Retry-After header or with an exponential backoff. To share a budget, use the same
+// RateLimiter in the transports of all clients:
+//
+//	limiter, _ := vpcbetav1.NewRateLimiter(&vpcbetav1.RateLimiterOptions{
+//		Global:     &vpcbetav1.RateLimit{Rate: 10, Burst: 10},
+//		Operations: map[string]*vpcbetav1.RateLimit{"list_snapshots": {Rate: 2}},
+//	})
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: vpcbetav1.NewRateLimitTransport(nil, limiter)})
+type RateLimitTransport struct {
+	// The base transport. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// The rate limiter. If nil, requests are not limited but still retried on 429 responses.
+	Limiter *RateLimiter
+
+	once           sync.Once
+	defaultLimiter *RateLimiter
+}
+
+// NewRateLimitTransport returns a new RateLimitTransport wrapping the given transport. If the
+// limiter is nil, requests are not limited but still retried on 429 responses.
+func NewRateLimitTransport(transport http.RoundTripper, limiter *RateLimiter) *RateLimitTransport {
+	if limiter == nil {
+		limiter, _ = NewRateLimiter(nil)
+	}
+	return &RateLimitTransport{
+		Transport: transport,
+		Limiter:   limiter,
+	}
+}
+
+// rateLimiter returns the rate limiter of the transport, or the unlimited one used by default.
+func (transport *RateLimitTransport) rateLimiter() *RateLimiter {
+	if transport.Limiter != nil {
+		return transport.Limiter
+	}
+	transport.once.Do(func() {
+		transport.defaultLimiter, _ = NewRateLimiter(nil)
+	})
+	return transport.defaultLimiter
+}
+
+// RoundTrip sends the request once the budgets allow it, retrying it on 429 responses.
+func (transport *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := transport.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	limiter := transport.rateLimiter()
+	options := &limiter.options
+
+	maxRetries := DefaultRateLimitRetries
+	if options.MaxRetries < 0 {
+		maxRetries = 0
+	} else if options.MaxRetries > 0 {
+		maxRetries = options.MaxRetries
+	}
+	backoff := &WaiterOptions{MinDelay: time.Second, MaxDelay: 30 * time.Second}
+	if options.MinDelay > 0 {
+		backoff.MinDelay = options.MinDelay
+	}
+	if options.MaxDelay > 0 {
+		backoff.MaxDelay = options.MaxDelay
+	}
+
+	var body []byte
+	var err error
+	if maxRetries > 0 {
+		if req, body, err = readRequestBody(req); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := req.Context()
+	operationID := operationIDOf(req)
+	for retry := 1; ; retry++ {
+		if err = limiter.Wait(ctx, operationID); err != nil {
+			closeRequestBody(req)
+			return nil, err
+		}
+		attempt := req
+		if retry > 1 {
+			attempt = req.Clone(ctx)
+			if body != nil {
+				attempt.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+		res, err := base.RoundTrip(attempt)
+		if err != nil || res.StatusCode != http.StatusTooManyRequests {
+			return res, err
+		}
+
+		delay, ok := retryAfter(res.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			delay = backoffDelay(backoff, retry)
+		}
+		canRetry := retry <= maxRetries
+		limiter.throttled(operationID, delay, canRetry)
+		if !canRetry {
+			return res, nil
+		}
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}
+}
+
+// retryAfter parses the value of a Retry-After header: a number of seconds or an HTTP date.
+func retryAfter(value string, now time.Time) (delay time.Duration, ok bool) {
+	if value == "" {
+		return
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay = date.Sub(now); delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return
+}
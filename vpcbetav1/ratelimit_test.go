@@ -0,0 +1,155 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`RateLimitTransport`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var faults *vpcbetav1.FaultTransport
+
+	// useLimiter configures the service with a rate limiter over a fault-injecting transport.
+	useLimiter := func(options *vpcbetav1.RateLimiterOptions) *vpcbetav1.RateLimiter {
+		limiter, err := vpcbetav1.NewRateLimiter(options)
+		Expect(err).To(BeNil())
+		service.SetHTTPClient(&http.Client{Transport: vpcbetav1.NewRateLimitTransport(faults, limiter)})
+		return limiter
+	}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		faults = vpcbetav1.NewFaultTransport(nil)
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Shares the global budget between goroutines`, func() {
+		limiter := useLimiter(&vpcbetav1.RateLimiterOptions{
+			Global: &vpcbetav1.RateLimit{Rate: 20, Burst: 1},
+		})
+		start := time.Now()
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, _, err := invoke(service, core.GET, "/vpcs", nil)
+				Expect(err).To(BeNil())
+			}()
+		}
+		wg.Wait()
+		Expect(time.Since(start)).To(BeNumerically(">=", 150*time.Millisecond))
+
+		metrics := limiter.Metrics()
+		Expect(metrics.Requests).To(Equal(int64(5)))
+		Expect(metrics.Waits).To(BeNumerically(">=", 3))
+		Expect(metrics.WaitTime).To(BeNumerically(">", 0))
+		Expect(limiter.OperationMetrics("list_vpcs")).To(Equal(metrics))
+	})
+	It(`Applies per-operation budgets`, func() {
+		limiter := useLimiter(&vpcbetav1.RateLimiterOptions{
+			Operations: map[string]*vpcbetav1.RateLimit{"list_vpcs": {Rate: 10}},
+		})
+		for i := 0; i < 3; i++ {
+			_, _, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+			Expect(err).To(BeNil())
+		}
+		Expect(limiter.OperationMetrics("create_vpc").Waits).To(BeZero())
+
+		for i := 0; i < 3; i++ {
+			_, _, err := invoke(service, core.GET, "/vpcs", nil)
+			Expect(err).To(BeNil())
+		}
+		Expect(limiter.OperationMetrics("list_vpcs").Waits).To(Equal(int64(2)))
+	})
+	It(`Retries 429 responses after the Retry-After delay`, func() {
+		faults.Rules = []*vpcbetav1.FaultRule{{
+			Operations: []string{"create_vpc"},
+			Schedule: []*vpcbetav1.Fault{
+				{Kind: vpcbetav1.FaultStatus, StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second},
+				vpcbetav1.NewStatusFault(http.StatusTooManyRequests),
+			},
+		}}
+		limiter := useLimiter(&vpcbetav1.RateLimiterOptions{MinDelay: 10 * time.Millisecond})
+
+		start := time.Now()
+		vpc, response, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		Expect(vpc["name"]).To(Equal("my-vpc"))
+		Expect(time.Since(start)).To(BeNumerically(">=", time.Second))
+
+		metrics := limiter.Metrics()
+		Expect(metrics.Requests).To(Equal(int64(3)))
+		Expect(metrics.Throttled).To(Equal(int64(2)))
+		Expect(metrics.Retries).To(Equal(int64(2)))
+		Expect(metrics.WaitTime).To(BeNumerically(">=", time.Second))
+	})
+	It(`Returns the 429 response when retries are exhausted`, func() {
+		faults.Rules = []*vpcbetav1.FaultRule{{Fault: vpcbetav1.NewStatusFault(http.StatusTooManyRequests)}}
+		limiter := useLimiter(&vpcbetav1.RateLimiterOptions{MaxRetries: -1})
+
+		_, response, err := invoke(service, core.GET, "/vpcs", nil)
+		Expect(vpcbetav1.IsRateLimited(vpcbetav1.NewAPIError(response, err))).To(BeTrue())
+		Expect(limiter.Metrics().Throttled).To(Equal(int64(1)))
+		Expect(limiter.Metrics().Retries).To(BeZero())
+	})
+	It(`Retries requests without a limiter`, func() {
+		faults.Rules = []*vpcbetav1.FaultRule{{
+			Schedule: []*vpcbetav1.Fault{{Kind: vpcbetav1.FaultStatus, StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second}},
+		}}
+		service.SetHTTPClient(&http.Client{Transport: &vpcbetav1.RateLimitTransport{Transport: faults}})
+
+		_, response, err := invoke(service, core.GET, "/vpcs", nil)
+		Expect(err).To(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+	It(`Invoke Wait with error`, func() {
+		limiter, err := vpcbetav1.NewRateLimiter(&vpcbetav1.RateLimiterOptions{
+			Global: &vpcbetav1.RateLimit{Rate: 0.1},
+		})
+		Expect(err).To(BeNil())
+		Expect(limiter.Wait(context.Background(), "list_vpcs")).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err = limiter.Wait(ctx, "list_vpcs")
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		Expect(limiter.Metrics().Requests).To(Equal(int64(1)))
+
+		_, err = vpcbetav1.NewRateLimiter(&vpcbetav1.RateLimiterOptions{
+			Operations: map[string]*vpcbetav1.RateLimit{"list_vpcs": {Rate: 0}},
+		})
+		Expect(err).ToNot(BeNil())
+	})
+})
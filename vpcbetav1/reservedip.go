@@ -0,0 +1,411 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// ProviderReservedAddressCount is the number of addresses of a subnet reserved by the provider:
+// the network address, the gateway, two addresses reserved for future use at the start of the
+// subnet, and the broadcast address at its end.
+const ProviderReservedAddressCount = 5
+
+// ReservedIPRollbackTimeout is the time allowed to release the reserved IPs of a failed
+// reservation. The release does not use the context of the reservation, which may be the
+// cause of the failure.
+const ReservedIPRollbackTimeout = 2 * time.Minute
+
+// ReservedIPCreateFunc reserves an address of a subnet with the given name. It is usually a
+// thin wrapper around CreateSubnetReservedIPWithContext, setting the subnet ID and the
+// Address and Name of the prototype, and returning its results.
+type ReservedIPCreateFunc func(ctx context.Context, address string, name string) (reservedIP interface{}, response *core.DetailedResponse, err error)
+
+// ReservedIPDeleteFunc releases a reserved IP of a subnet. It is usually a thin wrapper around
+// DeleteSubnetReservedIPWithContext, setting the subnet ID.
+type ReservedIPDeleteFunc func(ctx context.Context, id string) (response *core.DetailedResponse, err error)
+
+// AllocatedReservedIP : A reserved IP of a subnet known to a ReservedIPAllocator.
+type AllocatedReservedIP struct {
+	// The unique identifier of the reserved IP.
+	ID string
+
+	// The IP address.
+	Address string
+
+	// The name of the reserved IP.
+	Name string
+}
+
+// ReservedIPAllocator : An allocator of the reserved IPs of a subnet. It reserves contiguous
+// blocks or lists of addresses for static IP pools, skipping the addresses reserved by the
+// provider and the addresses already reserved, and releases them as a group.
+//
+// The reserved IPs of a group are named after its label: `<label>-1`, `<label>-2`, ... in
+// address order; a label cannot be reused until its group is released. A ReservedIPAllocator is
+// safe for concurrent use, and returns copies of the reserved IPs it knows.
+type ReservedIPAllocator struct {
+	subnet ipv4Range
+	create ReservedIPCreateFunc
+	delete ReservedIPDeleteFunc
+
+	mutex    sync.Mutex
+	reserved map[uint32]*AllocatedReservedIP
+}
+
+// NewReservedIPAllocator returns a new ReservedIPAllocator of the subnet with the given IPv4
+// CIDR block, with no reserved IPs but the ones of the provider.
+func NewReservedIPAllocator(subnetCIDR string, create ReservedIPCreateFunc, delete ReservedIPDeleteFunc) (allocator *ReservedIPAllocator, err error) {
+	if create == nil || delete == nil {
+		err = fmt.Errorf("create and delete functions cannot be nil")
+		return
+	}
+	subnet, err := parseIPv4Range(subnetCIDR)
+	if err != nil {
+		return
+	}
+	if subnet.last-subnet.first+1 <= ProviderReservedAddressCount {
+		err = fmt.Errorf("subnet %s has no address to reserve", subnetCIDR)
+		return
+	}
+	allocator = &ReservedIPAllocator{
+		subnet:   subnet,
+		create:   create,
+		delete:   delete,
+		reserved: make(map[uint32]*AllocatedReservedIP),
+	}
+	return
+}
+
+// LoadReservedIPAllocator returns a new ReservedIPAllocator of the subnet with the given IPv4
+// CIDR block, aware of the reserved IPs retrieved with the given List operation (see
+// CollectionListFunc): usually a wrapper around ListSubnetReservedIpsWithContext. R is the type
+// of the reserved IPs (the ReservedIP model).
+func LoadReservedIPAllocator[R any](ctx context.Context, subnetCIDR string, listReservedIPs CollectionListFunc, create ReservedIPCreateFunc, delete ReservedIPDeleteFunc) (allocator *ReservedIPAllocator, err error) {
+	if allocator, err = NewReservedIPAllocator(subnetCIDR, create, delete); err != nil {
+		return
+	}
+	CollectionItems[R](ctx, listReservedIPs, nil)(func(reservedIP R, itemErr error) bool {
+		var allocated *AllocatedReservedIP
+		if err = itemErr; err == nil {
+			if allocated, err = allocatedReservedIPOf(reservedIP); err == nil {
+				err = allocator.Track(allocated)
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// allocatedReservedIPOf returns the ID, address and name of a reserved IP model.
+func allocatedReservedIPOf(reservedIP interface{}) (allocated *AllocatedReservedIP, err error) {
+	object, err := ToJSONObject(reservedIP)
+	if err != nil {
+		return
+	}
+	allocated = &AllocatedReservedIP{}
+	allocated.ID, _ = object["id"].(string)
+	allocated.Address, _ = object["address"].(string)
+	allocated.Name, _ = object["name"].(string)
+	if allocated.ID == "" || allocated.Address == "" {
+		err = fmt.Errorf("%T has no id or address", reservedIP)
+	}
+	return
+}
+
+// Track records a reserved IP that was reserved outside of the allocator. Reserved IPs of the
+// provider (such as the gateway) are ignored.
+func (allocator *ReservedIPAllocator) Track(reservedIP *AllocatedReservedIP) error {
+	address, err := allocator.parseAddress(reservedIP.Address)
+	if err != nil {
+		return err
+	}
+	if allocator.isProviderReserved(address) {
+		return nil
+	}
+	tracked := *reservedIP
+	allocator.mutex.Lock()
+	defer allocator.mutex.Unlock()
+	allocator.reserved[address] = &tracked
+	return nil
+}
+
+// parseAddress parses an address of the subnet.
+func (allocator *ReservedIPAllocator) parseAddress(value string) (address uint32, err error) {
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return
+	}
+	if !addr.Is4() {
+		err = fmt.Errorf("%s is not an IPv4 address", value)
+		return
+	}
+	address = prefixRange(netip.PrefixFrom(addr, 32)).first
+	if address < allocator.subnet.first || address > allocator.subnet.last {
+		err = fmt.Errorf("%s is not in subnet %s", value, allocator.subnetCIDR())
+	}
+	return
+}
+
+// subnetCIDR returns the CIDR block of the subnet.
+func (allocator *ReservedIPAllocator) subnetCIDR() string {
+	size := uint64(allocator.subnet.last-allocator.subnet.first) + 1
+	prefixLength := 32
+	for ; size > 1; size >>= 1 {
+		prefixLength--
+	}
+	return rangeCIDR(allocator.subnet.first, prefixLength)
+}
+
+// isProviderReserved returns true if an address of the subnet is reserved by the provider.
+func (allocator *ReservedIPAllocator) isProviderReserved(address uint32) bool {
+	return address < allocator.subnet.first+ProviderReservedAddressCount-1 || address == allocator.subnet.last
+}
+
+// Reserved returns the reserved IPs known to the allocator, in address order.
+func (allocator *ReservedIPAllocator) Reserved() []*AllocatedReservedIP {
+	return allocator.group(func(*AllocatedReservedIP) bool { return true })
+}
+
+// Group returns the reserved IPs of the group with the given label, in address order.
+func (allocator *ReservedIPAllocator) Group(label string) []*AllocatedReservedIP {
+	return allocator.group(func(reservedIP *AllocatedReservedIP) bool {
+		return isGroupName(reservedIP.Name, label)
+	})
+}
+
+// group returns the reserved IPs that match, in address order.
+func (allocator *ReservedIPAllocator) group(match func(*AllocatedReservedIP) bool) (reservedIPs []*AllocatedReservedIP) {
+	allocator.mutex.Lock()
+	defer allocator.mutex.Unlock()
+	addresses := make([]uint32, 0, len(allocator.reserved))
+	for address, reservedIP := range allocator.reserved {
+		if match(reservedIP) {
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool { return addresses[i] < addresses[j] })
+	for _, address := range addresses {
+		reservedIP := *allocator.reserved[address]
+		reservedIPs = append(reservedIPs, &reservedIP)
+	}
+	return
+}
+
+// isGroupName returns true if a name is the name of a reserved IP of the group with the given
+// label.
+func isGroupName(name string, label string) bool {
+	suffix := strings.TrimPrefix(name, label+"-")
+	if suffix == name || suffix == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(suffix, 10, 32)
+	return err == nil
+}
+
+// ReserveBlock reserves the first contiguous block of count free addresses of the subnet, as
+// the group with the given label. If a reservation fails, the addresses reserved so far are
+// released and the error is returned.
+func (allocator *ReservedIPAllocator) ReserveBlock(ctx context.Context, label string, count int) (reservedIPs []*AllocatedReservedIP, err error) {
+	if count < 1 {
+		err = fmt.Errorf("count must be at least 1, not %d", count)
+		return
+	}
+	allocator.mutex.Lock()
+	first := uint64(allocator.subnet.first) + ProviderReservedAddressCount - 1
+	found := false
+	for start := first; start+uint64(count) <= uint64(allocator.subnet.last); start++ {
+		found = true
+		for address := start; address < start+uint64(count); address++ {
+			if _, ok := allocator.reserved[uint32(address)]; ok {
+				// Resume after the reserved address.
+				start, found = address, false
+				break
+			}
+		}
+		if found {
+			first = start
+			break
+		}
+	}
+	if !found {
+		allocator.mutex.Unlock()
+		err = fmt.Errorf("no block of %d free addresses in subnet %s", count, allocator.subnetCIDR())
+		return
+	}
+	addresses := make([]uint32, count)
+	for i := range addresses {
+		addresses[i] = uint32(first) + uint32(i)
+	}
+	allocator.mutex.Unlock()
+	return allocator.reserve(ctx, label, addresses)
+}
+
+// ReserveAddresses reserves the given addresses of the subnet, as the group with the given
+// label. If a reservation fails, the addresses reserved so far are released and the error is
+// returned.
+func (allocator *ReservedIPAllocator) ReserveAddresses(ctx context.Context, label string, addresses []string) (reservedIPs []*AllocatedReservedIP, err error) {
+	if len(addresses) == 0 {
+		err = fmt.Errorf("addresses cannot be empty")
+		return
+	}
+	parsed := make([]uint32, len(addresses))
+	for i, value := range addresses {
+		if parsed[i], err = allocator.parseAddress(value); err != nil {
+			return
+		}
+		if allocator.isProviderReserved(parsed[i]) {
+			err = fmt.Errorf("%s is reserved by the provider", value)
+			return
+		}
+	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i] < parsed[j] })
+	for i := 1; i < len(parsed); i++ {
+		if parsed[i] == parsed[i-1] {
+			err = fmt.Errorf("%s is listed more than once", ipv4Addr(parsed[i]))
+			return
+		}
+	}
+	return allocator.reserve(ctx, label, parsed)
+}
+
+// reserve reserves the given addresses, sorted, as the group with the given label, or none
+// of them.
+func (allocator *ReservedIPAllocator) reserve(ctx context.Context, label string, addresses []uint32) (reservedIPs []*AllocatedReservedIP, err error) {
+	if !namePattern.MatchString(label) || len(label)+1+len(strconv.Itoa(len(addresses))) > maxNameLength {
+		err = fmt.Errorf("label %q is not a valid name prefix", label)
+		return
+	}
+
+	// Claim the addresses, so that concurrent reservations do not race for them.
+	allocator.mutex.Lock()
+	for _, reservedIP := range allocator.reserved {
+		if isGroupName(reservedIP.Name, label) {
+			allocator.mutex.Unlock()
+			err = fmt.Errorf("label %q is already used by %s", label, reservedIP.Name)
+			return
+		}
+	}
+	for _, address := range addresses {
+		if reservedIP, ok := allocator.reserved[address]; ok {
+			allocator.mutex.Unlock()
+			err = fmt.Errorf("%s is already reserved by %s", reservedIP.Address, reservedIP.Name)
+			return
+		}
+	}
+	for i, address := range addresses {
+		allocator.reserved[address] = &AllocatedReservedIP{
+			Address: ipv4Addr(address).String(),
+			Name:    fmt.Sprintf("%s-%d", label, i+1),
+		}
+	}
+	allocator.mutex.Unlock()
+
+	for _, address := range addresses {
+		claimed := allocator.claimed(address)
+		result, response, createErr := allocator.create(ctx, claimed.Address, claimed.Name)
+		if createErr != nil {
+			err = fmt.Errorf("reserving %s: %w", claimed.Address, NewAPIError(response, createErr))
+			break
+		}
+		created, parseErr := allocatedReservedIPOf(result)
+		if parseErr != nil {
+			err = fmt.Errorf("reserving %s: %w", claimed.Address, parseErr)
+			break
+		}
+		claimed.ID = created.ID
+		allocator.mutex.Lock()
+		allocator.reserved[address].ID = created.ID
+		allocator.mutex.Unlock()
+		reservedIPs = append(reservedIPs, claimed)
+	}
+	if err == nil {
+		return
+	}
+
+	// Roll back: release the reserved IPs in reverse order, and unclaim the addresses. The
+	// reserved IPs that cannot be released remain known to the allocator. The context of the
+	// reservation may be canceled, so the release has its own.
+	rollbackCtx, cancel := context.WithTimeout(context.Background(), ReservedIPRollbackTimeout)
+	defer cancel()
+	var msgs []string
+	unclaimed := make(map[uint32]bool, len(addresses))
+	for _, address := range addresses {
+		unclaimed[address] = true
+	}
+	for i := len(reservedIPs) - 1; i >= 0; i-- {
+		response, deleteErr := allocator.delete(rollbackCtx, reservedIPs[i].ID)
+		if deleteErr != nil && !IsNotFound(NewAPIError(response, deleteErr)) {
+			msgs = append(msgs, fmt.Sprintf("releasing %s: %s", reservedIPs[i].Address, deleteErr.Error()))
+			address, _ := allocator.parseAddress(reservedIPs[i].Address)
+			unclaimed[address] = false
+		}
+	}
+	allocator.mutex.Lock()
+	for address, ok := range unclaimed {
+		if ok {
+			delete(allocator.reserved, address)
+		}
+	}
+	allocator.mutex.Unlock()
+	if len(msgs) > 0 {
+		err = fmt.Errorf("%w (rollback failed: %s)", err, strings.Join(msgs, "; "))
+	}
+	return nil, err
+}
+
+// claimed returns a copy of the reserved IP claimed for an address.
+func (allocator *ReservedIPAllocator) claimed(address uint32) *AllocatedReservedIP {
+	allocator.mutex.Lock()
+	defer allocator.mutex.Unlock()
+	reservedIP := *allocator.reserved[address]
+	return &reservedIP
+}
+
+// Release releases all the reserved IPs of the group with the given label. Reserved IPs that
+// no longer exist are ignored; the others that cannot be released remain in the group and the
+// errors are returned.
+func (allocator *ReservedIPAllocator) Release(ctx context.Context, label string) error {
+	var msgs []string
+	for _, reservedIP := range allocator.Group(label) {
+		response, err := allocator.delete(ctx, reservedIP.ID)
+		if err != nil && !IsNotFound(NewAPIError(response, err)) {
+			msgs = append(msgs, fmt.Sprintf("releasing %s: %s", reservedIP.Address, err.Error()))
+			continue
+		}
+		address, _ := allocator.parseAddress(reservedIP.Address)
+		allocator.mutex.Lock()
+		delete(allocator.reserved, address)
+		allocator.mutex.Unlock()
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("release of group %s failed: %s", label, strings.Join(msgs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testReservedIP and testReservedIPCollection mirror the shape of the generated models.
+type testReservedIP struct {
+	ID      *string `json:"id" validate:"required"`
+	Address *string `json:"address" validate:"required"`
+	Name    *string `json:"name" validate:"required"`
+}
+
+type testReservedIPCollection struct {
+	ReservedIps []testReservedIP `json:"reserved_ips"`
+}
+
+var _ = Describe(`ReservedIPAllocator`, func() {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var allocator *vpcbetav1.ReservedIPAllocator
+	// The reserved IPs of the subnet by ID, the addresses whose reservation fails, and the
+	// addresses whose reservation cancels ctx once done.
+	var reservedIPs map[string]*testReservedIP
+	var failing map[string]bool
+	var canceling map[string]bool
+
+	create := func(ctx context.Context, address string, name string) (interface{}, *core.DetailedResponse, error) {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		if canceling[address] {
+			defer cancel()
+		}
+		if failing[address] {
+			return nil, &core.DetailedResponse{StatusCode: http.StatusConflict}, fmt.Errorf("address %s is in use", address)
+		}
+		id := fmt.Sprintf("rip-%d", len(reservedIPs)+1)
+		reservedIPs[id] = &testReservedIP{ID: core.StringPtr(id), Address: core.StringPtr(address), Name: core.StringPtr(name)}
+		return reservedIPs[id], &core.DetailedResponse{StatusCode: http.StatusCreated}, nil
+	}
+	deleteFn := func(ctx context.Context, id string) (*core.DetailedResponse, error) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if _, ok := reservedIPs[id]; !ok {
+			return &core.DetailedResponse{StatusCode: http.StatusNotFound}, fmt.Errorf("reserved IP not found")
+		}
+		delete(reservedIPs, id)
+		return &core.DetailedResponse{StatusCode: http.StatusNoContent}, nil
+	}
+	addresses := func(reserved []*vpcbetav1.AllocatedReservedIP) (result []string) {
+		for _, reservedIP := range reserved {
+			result = append(result, reservedIP.Address)
+		}
+		return
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		reservedIPs = map[string]*testReservedIP{
+			"gateway": {ID: core.StringPtr("gateway"), Address: core.StringPtr("10.0.0.1"), Name: core.StringPtr("gateway")},
+			"rip-0":   {ID: core.StringPtr("rip-0"), Address: core.StringPtr("10.0.0.6"), Name: core.StringPtr("my-instance")},
+		}
+		failing = map[string]bool{}
+		canceling = map[string]bool{}
+		list := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			collection := &testReservedIPCollection{}
+			for _, reservedIP := range reservedIPs {
+				collection.ReservedIps = append(collection.ReservedIps, *reservedIP)
+			}
+			return collection, nil, nil
+		}
+		var err error
+		allocator, err = vpcbetav1.LoadReservedIPAllocator[testReservedIP](ctx, "10.0.0.0/28", list, create, deleteFn)
+		Expect(err).To(BeNil())
+	})
+	AfterEach(func() {
+		cancel()
+	})
+
+	It(`Reserves contiguous blocks after the provider-reserved addresses`, func() {
+		Expect(addresses(allocator.Reserved())).To(Equal([]string{"10.0.0.6"}))
+
+		reserved, err := allocator.ReserveBlock(ctx, "appliance", 3)
+		Expect(err).To(BeNil())
+		Expect(addresses(reserved)).To(Equal([]string{"10.0.0.7", "10.0.0.8", "10.0.0.9"}))
+		Expect(reserved[0].Name).To(Equal("appliance-1"))
+		Expect(reserved[2].Name).To(Equal("appliance-3"))
+		Expect(reserved[0].ID).ToNot(BeEmpty())
+
+		reserved, err = allocator.ReserveBlock(ctx, "pool", 1)
+		Expect(err).To(BeNil())
+		Expect(addresses(reserved)).To(Equal([]string{"10.0.0.4"}))
+
+		// 10.0.0.10 to 10.0.0.14 are free, 10.0.0.15 is the broadcast address.
+		_, err = allocator.ReserveBlock(ctx, "large", 6)
+		Expect(err).ToNot(BeNil())
+		reserved, err = allocator.ReserveBlock(ctx, "large", 5)
+		Expect(err).To(BeNil())
+		Expect(addresses(reserved)).To(Equal([]string{"10.0.0.10", "10.0.0.11", "10.0.0.12", "10.0.0.13", "10.0.0.14"}))
+	})
+	It(`Reserves pinned addresses`, func() {
+		reserved, err := allocator.ReserveAddresses(ctx, "vip", []string{"10.0.0.12", "10.0.0.10"})
+		Expect(err).To(BeNil())
+		Expect(addresses(reserved)).To(Equal([]string{"10.0.0.10", "10.0.0.12"}))
+		Expect(addresses(allocator.Group("vip"))).To(Equal([]string{"10.0.0.10", "10.0.0.12"}))
+
+		for _, invalid := range [][]string{{"10.0.0.2"}, {"10.0.0.15"}, {"10.0.1.4"}, {"10.0.0.6"}, {"10.0.0.4", "10.0.0.4"}, {}} {
+			_, err = allocator.ReserveAddresses(ctx, "other", invalid)
+			Expect(err).ToNot(BeNil(), fmt.Sprint(invalid))
+		}
+		_, err = allocator.ReserveAddresses(ctx, "Not_A_Name", []string{"10.0.0.4"})
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Rolls back partial reservations`, func() {
+		failing["10.0.0.9"] = true
+		_, err := allocator.ReserveBlock(ctx, "appliance", 3)
+		Expect(err).ToNot(BeNil())
+		Expect(vpcbetav1.IsConflict(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("reserving 10.0.0.9"))
+		Expect(reservedIPs).To(HaveLen(2))
+		Expect(allocator.Group("appliance")).To(BeEmpty())
+		Expect(addresses(allocator.Reserved())).To(Equal([]string{"10.0.0.6"}))
+	})
+	It(`Rolls back reservations canceled by the caller`, func() {
+		canceling["10.0.0.8"] = true
+		_, err := allocator.ReserveBlock(ctx, "appliance", 3)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(err.Error()).To(ContainSubstring("reserving 10.0.0.9"))
+		Expect(err.Error()).ToNot(ContainSubstring("rollback failed"))
+		Expect(reservedIPs).To(HaveLen(2))
+		Expect(addresses(allocator.Reserved())).To(Equal([]string{"10.0.0.6"}))
+	})
+	It(`Releases groups`, func() {
+		_, err := allocator.ReserveBlock(ctx, "appliance", 2)
+		Expect(err).To(BeNil())
+		_, err = allocator.ReserveBlock(ctx, "appliance-pool", 2)
+		Expect(err).To(BeNil())
+		Expect(reservedIPs).To(HaveLen(6))
+
+		Expect(allocator.Release(ctx, "appliance")).To(Succeed())
+		Expect(reservedIPs).To(HaveLen(4))
+		Expect(addresses(allocator.Group("appliance"))).To(BeEmpty())
+		Expect(addresses(allocator.Group("appliance-pool"))).To(Equal([]string{"10.0.0.7", "10.0.0.8"}))
+
+		// Released addresses are free again.
+		reserved, err := allocator.ReserveBlock(ctx, "other", 3)
+		Expect(err).To(BeNil())
+		Expect(addresses(reserved)).To(Equal([]string{"10.0.0.9", "10.0.0.10", "10.0.0.11"}))
+		reserved, err = allocator.ReserveBlock(ctx, "spare", 2)
+		Expect(err).To(BeNil())
+		Expect(addresses(reserved)).To(Equal([]string{"10.0.0.4", "10.0.0.5"}))
+	})
+	It(`Rejects labels in use until their group is released`, func() {
+		_, err := allocator.ReserveBlock(ctx, "appliance", 2)
+		Expect(err).To(BeNil())
+		_, err = allocator.ReserveBlock(ctx, "appliance", 1)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`label "appliance" is already used`))
+		_, err = allocator.ReserveAddresses(ctx, "appliance", []string{"10.0.0.12"})
+		Expect(err).ToNot(BeNil())
+		Expect(reservedIPs).To(HaveLen(4))
+
+		Expect(allocator.Release(ctx, "appliance")).To(Succeed())
+		reserved, err := allocator.ReserveAddresses(ctx, "appliance", []string{"10.0.0.12"})
+		Expect(err).To(BeNil())
+		Expect(reserved[0].Name).To(Equal("appliance-1"))
+	})
+	It(`Returns copies of the reserved IPs`, func() {
+		reserved, err := allocator.ReserveBlock(ctx, "appliance", 1)
+		Expect(err).To(BeNil())
+		reserved[0].Name = "changed"
+		allocator.Group("appliance")[0].ID = "changed"
+		allocator.Reserved()[0].Address = "changed"
+
+		group := allocator.Group("appliance")
+		Expect(group).To(HaveLen(1))
+		Expect(group[0].ID).ToNot(Equal("changed"))
+		Expect(addresses(allocator.Reserved())).To(Equal([]string{"10.0.0.4", "10.0.0.6"}))
+	})
+})
@@ -0,0 +1,505 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// DefaultSecurityGroupRuleConcurrency is the number of rule changes ReconcileSecurityGroupRules
+// applies in parallel, unless configured otherwise.
+const DefaultSecurityGroupRuleConcurrency = 4
+
+// The actions of the changes of a security group rule reconciliation.
+const (
+	SecurityGroupRuleActionCreate = "create"
+	SecurityGroupRuleActionUpdate = "update"
+	SecurityGroupRuleActionDelete = "delete"
+)
+
+// SecurityGroupRuleEndpoint : The normalized remote or local endpoint of a security group
+// rule: a CIDR block, an IP address, or a security group identified by any of its ID, CRN,
+// href or name.
+type SecurityGroupRuleEndpoint struct {
+	CIDRBlock string
+	Address   string
+	ID        string
+	CRN       string
+	Href      string
+	Name      string
+}
+
+// Matches returns true if both endpoints denote the same CIDR block, address or security
+// group.
+func (endpoint *SecurityGroupRuleEndpoint) Matches(other *SecurityGroupRuleEndpoint) bool {
+	switch {
+	case endpoint.CIDRBlock != "" || other.CIDRBlock != "":
+		return endpoint.CIDRBlock == other.CIDRBlock
+	case endpoint.Address != "" || other.Address != "":
+		return endpoint.Address == other.Address
+	}
+	same := func(a, b string) bool { return a != "" && a == b }
+	return same(endpoint.ID, other.ID) || same(endpoint.CRN, other.CRN) ||
+		same(endpoint.Href, other.Href) || same(endpoint.Name, other.Name)
+}
+
+// String returns the CIDR block, address or first identifier of the security group.
+func (endpoint *SecurityGroupRuleEndpoint) String() string {
+	for _, value := range []string{endpoint.CIDRBlock, endpoint.Address, endpoint.ID, endpoint.CRN, endpoint.Href, endpoint.Name} {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// object returns the JSON object of the endpoint, as used in rule prototypes and patches.
+func (endpoint *SecurityGroupRuleEndpoint) object() map[string]interface{} {
+	object := make(map[string]interface{})
+	for key, value := range map[string]string{"cidr_block": endpoint.CIDRBlock, "address": endpoint.Address,
+		"id": endpoint.ID, "crn": endpoint.CRN, "href": endpoint.Href, "name": endpoint.Name} {
+		if value != "" {
+			object[key] = value
+		}
+	}
+	return object
+}
+
+// NormalizedSecurityGroupRule : A security group rule normalized from any of the polymorphic
+// rule, rule prototype or rule patch models, so that rules can be compared: the defaults of the
+// API are made explicit (IP version `ipv4`, ports 1 to 65535 for TCP and UDP, and remote and
+// local endpoints `0.0.0.0/0`) and CIDR blocks are canonical.
+type NormalizedSecurityGroupRule struct {
+	// The unique identifier of an existing rule.
+	ID string
+
+	Direction string
+	IPVersion string
+	Protocol  string
+
+	// The port range of TCP and UDP rules.
+	PortMin *int64
+	PortMax *int64
+
+	// The ICMP type and code of ICMP rules, if any.
+	Type *int64
+	Code *int64
+
+	Remote *SecurityGroupRuleEndpoint
+	Local  *SecurityGroupRuleEndpoint
+}
+
+// anyIPv4 is the CIDR block of all IPv4 addresses.
+const anyIPv4 = "0.0.0.0/0"
+
+// NormalizeSecurityGroupRule returns the normalized form of a security group rule model
+// (SecurityGroupRule..., SecurityGroupRulePrototype... or a JSON object of the same shape).
+func NormalizeSecurityGroupRule(model interface{}) (rule *NormalizedSecurityGroupRule, err error) {
+	object, err := ToJSONObject(model)
+	if err != nil {
+		return
+	}
+	rule = &NormalizedSecurityGroupRule{IPVersion: "ipv4"}
+	rule.ID, _ = object["id"].(string)
+	rule.Direction, _ = object["direction"].(string)
+	rule.Protocol, _ = object["protocol"].(string)
+	rule.Protocol = strings.ToLower(rule.Protocol)
+	if ipVersion, _ := object["ip_version"].(string); ipVersion != "" {
+		rule.IPVersion = ipVersion
+	}
+	if rule.Direction == "" || rule.Protocol == "" {
+		return nil, fmt.Errorf("security group rule %v has no direction or protocol", object)
+	}
+
+	integer := func(key string) *int64 {
+		if value, ok := object[key].(float64); ok {
+			return core.Int64Ptr(int64(value))
+		}
+		return nil
+	}
+	switch rule.Protocol {
+	case "tcp", "udp":
+		rule.PortMin, rule.PortMax = integer("port_min"), integer("port_max")
+		if rule.PortMin == nil {
+			rule.PortMin = core.Int64Ptr(1)
+		}
+		if rule.PortMax == nil {
+			rule.PortMax = core.Int64Ptr(65535)
+		}
+	case "icmp":
+		rule.Type, rule.Code = integer("type"), integer("code")
+	}
+
+	if rule.Remote, err = normalizeSecurityGroupRuleEndpoint(object["remote"]); err != nil {
+		return nil, err
+	}
+	if rule.Local, err = normalizeSecurityGroupRuleEndpoint(object["local"]); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// normalizeSecurityGroupRuleEndpoint returns the normalized form of the remote or local
+// endpoint of a rule.
+func normalizeSecurityGroupRuleEndpoint(value interface{}) (endpoint *SecurityGroupRuleEndpoint, err error) {
+	object, _ := value.(map[string]interface{})
+	if len(object) == 0 {
+		return &SecurityGroupRuleEndpoint{CIDRBlock: anyIPv4}, nil
+	}
+	endpoint = &SecurityGroupRuleEndpoint{}
+	endpoint.CIDRBlock, _ = object["cidr_block"].(string)
+	endpoint.Address, _ = object["address"].(string)
+	endpoint.ID, _ = object["id"].(string)
+	endpoint.CRN, _ = object["crn"].(string)
+	endpoint.Href, _ = object["href"].(string)
+	endpoint.Name, _ = object["name"].(string)
+	if endpoint.CIDRBlock != "" {
+		prefix, parseErr := netip.ParsePrefix(endpoint.CIDRBlock)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		endpoint.CIDRBlock = prefix.Masked().String()
+	}
+	if endpoint.String() == "" {
+		return nil, fmt.Errorf("security group rule endpoint %v has no CIDR block, address or identifier", object)
+	}
+	return
+}
+
+// Equal returns true if both rules have the same effect.
+func (rule *NormalizedSecurityGroupRule) Equal(other *NormalizedSecurityGroupRule) bool {
+	return rule.Direction == other.Direction && rule.IPVersion == other.IPVersion &&
+		rule.Protocol == other.Protocol && equalInt64Ptr(rule.PortMin, other.PortMin) &&
+		equalInt64Ptr(rule.PortMax, other.PortMax) && equalInt64Ptr(rule.Type, other.Type) &&
+		equalInt64Ptr(rule.Code, other.Code) && rule.Remote.Matches(other.Remote) &&
+		rule.Local.Matches(other.Local)
+}
+
+// equalInt64Ptr returns true if both values are nil or equal.
+func equalInt64Ptr(a *int64, b *int64) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+// String returns a short description of the rule, such as `inbound tcp 22-22 from 10.0.0.0/8`.
+func (rule *NormalizedSecurityGroupRule) String() string {
+	description := rule.Direction + " " + rule.Protocol
+	if rule.PortMin != nil {
+		description += fmt.Sprintf(" %d-%d", *rule.PortMin, *rule.PortMax)
+	}
+	if rule.Type != nil {
+		description += fmt.Sprintf(" type %d", *rule.Type)
+	}
+	if rule.Code != nil {
+		description += fmt.Sprintf(" code %d", *rule.Code)
+	}
+	if rule.Direction == "outbound" {
+		return description + " to " + rule.Remote.String()
+	}
+	return description + " from " + rule.Remote.String()
+}
+
+// patchTo returns the patch updating the rule to the desired one, which has the same protocol.
+func (rule *NormalizedSecurityGroupRule) patchTo(desired *NormalizedSecurityGroupRule) map[string]interface{} {
+	patch := make(map[string]interface{})
+	if rule.Direction != desired.Direction {
+		patch["direction"] = desired.Direction
+	}
+	if rule.IPVersion != desired.IPVersion {
+		patch["ip_version"] = desired.IPVersion
+	}
+	for key, values := range map[string][2]*int64{"port_min": {rule.PortMin, desired.PortMin},
+		"port_max": {rule.PortMax, desired.PortMax}, "type": {rule.Type, desired.Type},
+		"code": {rule.Code, desired.Code}} {
+		if !equalInt64Ptr(values[0], values[1]) {
+			if values[1] == nil {
+				patch[key] = nil
+			} else {
+				patch[key] = *values[1]
+			}
+		}
+	}
+	if !rule.Remote.Matches(desired.Remote) {
+		patch["remote"] = desired.Remote.object()
+	}
+	if !rule.Local.Matches(desired.Local) {
+		patch["local"] = desired.Local.object()
+	}
+	return patch
+}
+
+// SecurityGroupRuleReconcileOptions : The SecurityGroupRuleReconcileOptions struct configures
+// ReconcileSecurityGroupRules. The functions are usually thin wrappers around the generated
+// ListSecurityGroupRulesWithContext, CreateSecurityGroupRuleWithContext,
+// UpdateSecurityGroupRuleWithContext and DeleteSecurityGroupRuleWithContext operations.
+type SecurityGroupRuleReconcileOptions struct {
+	// Lists the rules of a security group (the SecurityGroupRuleCollection model).
+	List func(ctx context.Context, securityGroupID string) (collection interface{}, response *core.DetailedResponse, err error)
+
+	// Creates a rule from one of the desired rule prototypes.
+	Create func(ctx context.Context, securityGroupID string, prototype interface{}) (rule interface{}, response *core.DetailedResponse, err error)
+
+	// Updates a rule with a patch (see SecurityGroupRulePatch.AsPatch). If nil, rules are
+	// deleted and created instead of being updated.
+	Update func(ctx context.Context, securityGroupID string, ruleID string, patch map[string]interface{}) (rule interface{}, response *core.DetailedResponse, err error)
+
+	// Deletes a rule.
+	Delete func(ctx context.Context, securityGroupID string, ruleID string) (response *core.DetailedResponse, err error)
+
+	// If true, nothing is changed: the report only lists the changes to apply.
+	PlanOnly bool
+
+	// The number of changes to apply in parallel. If not set, DefaultSecurityGroupRuleConcurrency
+	// is used.
+	Concurrency int
+}
+
+// SecurityGroupRuleChange : A change of a security group rule reconciliation.
+type SecurityGroupRuleChange struct {
+	// The action (one of the SecurityGroupRuleAction... constants).
+	Action string
+
+	// The current rule, for updates and deletions.
+	Current *NormalizedSecurityGroupRule
+
+	// The desired rule, for creations and updates, and its prototype.
+	Desired   *NormalizedSecurityGroupRule
+	Prototype interface{}
+
+	// The patch of an update.
+	Patch map[string]interface{}
+
+	// The ID of the rule: the created rule, or the updated or deleted one.
+	RuleID string
+
+	// Whether the change was applied (false in plan-only mode).
+	Applied bool
+
+	// Whether the change was skipped: deletions are skipped when a creation or an update
+	// failed, so that no access is lost before the desired rules are in place.
+	Skipped bool
+
+	// The error that prevented the change, if any.
+	Err error
+}
+
+// SecurityGroupRuleReport : The outcome of a security group rule reconciliation.
+type SecurityGroupRuleReport struct {
+	// The changes: creations, updates and deletions, in the order they are applied.
+	Changes []*SecurityGroupRuleChange
+
+	// The current rules that already match a desired rule.
+	Unchanged []*NormalizedSecurityGroupRule
+}
+
+// Failed returns the changes that could not be applied or were skipped.
+func (report *SecurityGroupRuleReport) Failed() (failed []*SecurityGroupRuleChange) {
+	for _, change := range report.Changes {
+		if change.Err != nil || change.Skipped {
+			failed = append(failed, change)
+		}
+	}
+	return
+}
+
+// SecurityGroupRuleError : The error returned by ReconcileSecurityGroupRules when some changes
+// could not be applied.
+type SecurityGroupRuleError struct {
+	// The changes that could not be applied or were skipped.
+	Failed []*SecurityGroupRuleChange
+}
+
+// Error returns the error message.
+func (ruleErr *SecurityGroupRuleError) Error() string {
+	var msgs []string
+	skipped := 0
+	for _, change := range ruleErr.Failed {
+		if change.Err != nil {
+			rule := change.Desired
+			if rule == nil {
+				rule = change.Current
+			}
+			msgs = append(msgs, fmt.Sprintf("%s %s: %s", change.Action, rule.String(), change.Err.Error()))
+		} else {
+			skipped++
+		}
+	}
+	return fmt.Sprintf("reconciliation failed for %d rule(s), %d skipped: %s", len(msgs), skipped, strings.Join(msgs, "; "))
+}
+
+// ReconcileSecurityGroupRules makes the rules of a security group match the desired rule
+// prototypes (SecurityGroupRulePrototype... models or JSON objects of the same shape).
+//
+// Current and desired rules are normalized (see NormalizeSecurityGroupRule) and compared:
+// current rules matching a desired rule are kept, current rules with the same direction and
+// protocol as a missing desired rule are updated, the remaining desired rules are created and
+// the remaining current rules are deleted. Creations and updates are applied first, in
+// parallel, then deletions.
+//
+// The report lists every change; if some of them could not be applied, a
+// *SecurityGroupRuleError is returned along with the report.
+func ReconcileSecurityGroupRules(ctx context.Context, securityGroupID string, desired []interface{}, options *SecurityGroupRuleReconcileOptions) (report *SecurityGroupRuleReport, err error) {
+	if options == nil || options.List == nil || options.Create == nil || options.Delete == nil {
+		err = fmt.Errorf("list, create and delete functions cannot be nil")
+		return
+	}
+	collection, response, err := options.List(ctx, securityGroupID)
+	if err != nil {
+		err = NewAPIError(response, err)
+		return
+	}
+	object, err := ToJSONObject(collection)
+	if err != nil {
+		return
+	}
+	var current []*NormalizedSecurityGroupRule
+	items, _ := object["rules"].([]interface{})
+	for _, item := range items {
+		rule, normalizeErr := NormalizeSecurityGroupRule(item)
+		if normalizeErr != nil {
+			return nil, normalizeErr
+		}
+		current = append(current, rule)
+	}
+	desiredRules := make([]*NormalizedSecurityGroupRule, len(desired))
+	for i, prototype := range desired {
+		if desiredRules[i], err = NormalizeSecurityGroupRule(prototype); err != nil {
+			return
+		}
+	}
+
+	report = planSecurityGroupRules(current, desiredRules, desired, options.Update != nil)
+	if options.PlanOnly {
+		return
+	}
+
+	var upserts, deletions []*SecurityGroupRuleChange
+	for _, change := range report.Changes {
+		if change.Action == SecurityGroupRuleActionDelete {
+			deletions = append(deletions, change)
+		} else {
+			upserts = append(upserts, change)
+		}
+	}
+	applySecurityGroupRuleChanges(ctx, securityGroupID, upserts, options)
+	if len(report.Failed()) > 0 {
+		for _, change := range deletions {
+			change.Skipped = true
+		}
+	} else {
+		applySecurityGroupRuleChanges(ctx, securityGroupID, deletions, options)
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		err = &SecurityGroupRuleError{Failed: failed}
+	}
+	return
+}
+
+// planSecurityGroupRules returns the changes turning the current rules into the desired ones.
+func planSecurityGroupRules(current []*NormalizedSecurityGroupRule, desired []*NormalizedSecurityGroupRule, prototypes []interface{}, updates bool) (report *SecurityGroupRuleReport) {
+	report = &SecurityGroupRuleReport{}
+	matched := make([]bool, len(current))
+	var missing []int
+	for i, desiredRule := range desired {
+		found := false
+		for j, currentRule := range current {
+			if !matched[j] && currentRule.Equal(desiredRule) {
+				matched[j], found = true, true
+				report.Unchanged = append(report.Unchanged, currentRule)
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, i)
+		}
+	}
+
+	var deletions []*SecurityGroupRuleChange
+	for _, i := range missing {
+		change := &SecurityGroupRuleChange{Action: SecurityGroupRuleActionCreate, Desired: desired[i], Prototype: prototypes[i]}
+		for j, currentRule := range current {
+			if updates && !matched[j] && currentRule.Direction == desired[i].Direction && currentRule.Protocol == desired[i].Protocol {
+				matched[j] = true
+				change.Action = SecurityGroupRuleActionUpdate
+				change.Current = currentRule
+				change.RuleID = currentRule.ID
+				change.Patch = currentRule.patchTo(desired[i])
+				break
+			}
+		}
+		report.Changes = append(report.Changes, change)
+	}
+	for j, currentRule := range current {
+		if !matched[j] {
+			deletions = append(deletions, &SecurityGroupRuleChange{Action: SecurityGroupRuleActionDelete,
+				Current: currentRule, RuleID: currentRule.ID})
+		}
+	}
+	report.Changes = append(report.Changes, deletions...)
+	return
+}
+
+// applySecurityGroupRuleChanges applies changes in parallel.
+func applySecurityGroupRuleChanges(ctx context.Context, securityGroupID string, changes []*SecurityGroupRuleChange, options *SecurityGroupRuleReconcileOptions) {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSecurityGroupRuleConcurrency
+	}
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, change := range changes {
+		wg.Add(1)
+		go func(change *SecurityGroupRuleChange) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				change.Skipped = true
+				return
+			}
+			defer func() { <-semaphore }()
+
+			var rule interface{}
+			var response *core.DetailedResponse
+			var err error
+			switch change.Action {
+			case SecurityGroupRuleActionCreate:
+				if rule, response, err = options.Create(ctx, securityGroupID, change.Prototype); err == nil {
+					if created, normalizeErr := NormalizeSecurityGroupRule(rule); normalizeErr == nil {
+						change.RuleID = created.ID
+					}
+				}
+			case SecurityGroupRuleActionUpdate:
+				_, response, err = options.Update(ctx, securityGroupID, change.RuleID, change.Patch)
+			case SecurityGroupRuleActionDelete:
+				response, err = options.Delete(ctx, securityGroupID, change.RuleID)
+				if IsNotFound(NewAPIError(response, err)) {
+					err = nil
+				}
+			}
+			change.Err = NewAPIError(response, err)
+			change.Applied = change.Err == nil
+		}(change)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,174 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`ReconcileSecurityGroupRules`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var sgID string
+	var options *vpcbetav1.SecurityGroupRuleReconcileOptions
+
+	rulesPath := func() string {
+		return fmt.Sprintf("/security_groups/%s/rules", sgID)
+	}
+	// currentRules returns the descriptions of the rules of the security group, sorted.
+	currentRules := func() (descriptions []string) {
+		result, _, err := invoke(service, core.GET, rulesPath(), nil)
+		Expect(err).To(BeNil())
+		for _, item := range result["rules"].([]interface{}) {
+			rule, err := vpcbetav1.NormalizeSecurityGroupRule(item)
+			Expect(err).To(BeNil())
+			descriptions = append(descriptions, rule.String())
+		}
+		sort.Strings(descriptions)
+		return
+	}
+	actions := func(report *vpcbetav1.SecurityGroupRuleReport) (result []string) {
+		for _, change := range report.Changes {
+			rule := change.Desired
+			if rule == nil {
+				rule = change.Current
+			}
+			result = append(result, change.Action+" "+rule.String())
+		}
+		return
+	}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+		vpc, _, err := invoke(service, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		sgID = vpc["default_security_group"].(map[string]interface{})["id"].(string)
+		for _, rule := range []map[string]interface{}{
+			{"direction": "inbound", "protocol": "tcp", "port_min": 22, "port_max": 22,
+				"remote": map[string]interface{}{"cidr_block": "10.0.0.0/8"}},
+			{"direction": "inbound", "protocol": "tcp", "port_min": 80, "port_max": 80},
+			{"direction": "outbound", "protocol": "udp", "port_min": 53, "port_max": 53,
+				"remote": map[string]interface{}{"address": "10.0.0.2"}},
+		} {
+			_, _, err = invoke(service, core.POST, rulesPath(), rule)
+			Expect(err).To(BeNil())
+		}
+
+		options = &vpcbetav1.SecurityGroupRuleReconcileOptions{
+			List: func(ctx context.Context, securityGroupID string) (interface{}, *core.DetailedResponse, error) {
+				return invoke(service, core.GET, fmt.Sprintf("/security_groups/%s/rules", securityGroupID), nil)
+			},
+			Create: func(ctx context.Context, securityGroupID string, prototype interface{}) (interface{}, *core.DetailedResponse, error) {
+				return invoke(service, core.POST, fmt.Sprintf("/security_groups/%s/rules", securityGroupID), prototype)
+			},
+			Update: func(ctx context.Context, securityGroupID string, ruleID string, patch map[string]interface{}) (interface{}, *core.DetailedResponse, error) {
+				return invoke(service, core.PATCH, fmt.Sprintf("/security_groups/%s/rules/%s", securityGroupID, ruleID), patch)
+			},
+			Delete: func(ctx context.Context, securityGroupID string, ruleID string) (*core.DetailedResponse, error) {
+				_, response, err := invoke(service, core.DELETE, fmt.Sprintf("/security_groups/%s/rules/%s", securityGroupID, ruleID), nil)
+				return response, err
+			},
+		}
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	desired := func() []interface{} {
+		return []interface{}{
+			// The default rules, with the remote security group by ID and the default remote.
+			map[string]interface{}{"direction": "inbound", "protocol": "all", "remote": map[string]interface{}{"id": sgID}},
+			map[string]interface{}{"direction": "outbound", "protocol": "all"},
+			// Unchanged, with implicit defaults.
+			map[string]interface{}{"direction": "inbound", "protocol": "TCP", "port_min": 22, "port_max": 22,
+				"ip_version": "ipv4", "remote": map[string]interface{}{"cidr_block": "10.0.0.0/8"}},
+			// Replaces port 80.
+			map[string]interface{}{"direction": "inbound", "protocol": "tcp", "port_min": 443, "port_max": 443},
+			map[string]interface{}{"direction": "inbound", "protocol": "icmp", "type": 8},
+		}
+	}
+
+	It(`Plans the changes only`, func() {
+		before := currentRules()
+		options.PlanOnly = true
+		report, err := vpcbetav1.ReconcileSecurityGroupRules(context.Background(), sgID, desired(), options)
+		Expect(err).To(BeNil())
+		Expect(report.Unchanged).To(HaveLen(3))
+		Expect(actions(report)).To(Equal([]string{
+			"update inbound tcp 443-443 from 0.0.0.0/0",
+			"create inbound icmp type 8 from 0.0.0.0/0",
+			"delete outbound udp 53-53 to 10.0.0.2",
+		}))
+		Expect(report.Changes[0].Patch).To(Equal(map[string]interface{}{"port_min": int64(443), "port_max": int64(443)}))
+		Expect(report.Changes[0].Applied).To(BeFalse())
+		Expect(currentRules()).To(Equal(before))
+	})
+	It(`Applies the changes`, func() {
+		report, err := vpcbetav1.ReconcileSecurityGroupRules(context.Background(), sgID, desired(), options)
+		Expect(err).To(BeNil())
+		Expect(report.Changes).To(HaveLen(3))
+		for _, change := range report.Changes {
+			Expect(change.Applied).To(BeTrue())
+			Expect(change.RuleID).ToNot(BeEmpty())
+		}
+		Expect(currentRules()).To(Equal([]string{
+			"inbound all from " + sgID,
+			"inbound icmp type 8 from 0.0.0.0/0",
+			"inbound tcp 22-22 from 10.0.0.0/8",
+			"inbound tcp 443-443 from 0.0.0.0/0",
+			"outbound all to 0.0.0.0/0",
+		}))
+
+		// Reconciled rules do not change.
+		report, err = vpcbetav1.ReconcileSecurityGroupRules(context.Background(), sgID, desired(), options)
+		Expect(err).To(BeNil())
+		Expect(report.Changes).To(BeEmpty())
+		Expect(report.Unchanged).To(HaveLen(5))
+	})
+	It(`Skips deletions if a creation fails`, func() {
+		create := options.Create
+		options.Create = func(ctx context.Context, securityGroupID string, prototype interface{}) (interface{}, *core.DetailedResponse, error) {
+			if prototype.(map[string]interface{})["protocol"] == "icmp" {
+				return invoke(service, core.POST, fmt.Sprintf("/security_groups/%s/rules", securityGroupID), map[string]interface{}{})
+			}
+			return create(ctx, securityGroupID, prototype)
+		}
+		options.Update = nil
+		report, err := vpcbetav1.ReconcileSecurityGroupRules(context.Background(), sgID, desired(), options)
+		Expect(err).To(BeAssignableToTypeOf(&vpcbetav1.SecurityGroupRuleError{}))
+		Expect(err.Error()).To(HavePrefix("reconciliation failed for 1 rule(s), 2 skipped: create inbound icmp type 8"))
+		Expect(actions(report)).To(Equal([]string{
+			"create inbound tcp 443-443 from 0.0.0.0/0",
+			"create inbound icmp type 8 from 0.0.0.0/0",
+			"delete inbound tcp 80-80 from 0.0.0.0/0",
+			"delete outbound udp 53-53 to 10.0.0.2",
+		}))
+		Expect(report.Changes[0].Applied).To(BeTrue())
+		Expect(vpcbetav1.IsValidationError(report.Changes[1].Err)).To(BeTrue())
+		Expect(report.Changes[2].Skipped).To(BeTrue())
+		Expect(currentRules()).To(ContainElement("inbound tcp 80-80 from 0.0.0.0/0"))
+	})
+})
@@ -0,0 +1,421 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// The kinds of resources known to Teardown.
+const (
+	TeardownKindAddressPrefix   = "address_prefix"
+	TeardownKindBareMetalServer = "bare_metal_server"
+	TeardownKindEndpointGateway = "endpoint_gateway"
+	TeardownKindFloatingIP      = "floating_ip"
+	TeardownKindInstance        = "instance"
+	TeardownKindLoadBalancer    = "load_balancer"
+	TeardownKindNetworkACL      = "network_acl"
+	TeardownKindPublicGateway   = "public_gateway"
+	TeardownKindRoutingTable    = "routing_table"
+	TeardownKindSecurityGroup   = "security_group"
+	TeardownKindSubnet          = "subnet"
+	TeardownKindVPC             = "vpc"
+	TeardownKindVPNGateway      = "vpn_gateway"
+	TeardownKindVPNServer       = "vpn_server"
+)
+
+// teardownKinds are the kinds of resources known to Teardown.
+var teardownKinds = map[string]bool{
+	TeardownKindAddressPrefix: true, TeardownKindBareMetalServer: true, TeardownKindEndpointGateway: true,
+	TeardownKindFloatingIP: true, TeardownKindInstance: true, TeardownKindLoadBalancer: true,
+	TeardownKindNetworkACL: true, TeardownKindPublicGateway: true, TeardownKindRoutingTable: true,
+	TeardownKindSecurityGroup: true, TeardownKindSubnet: true, TeardownKindVPC: true,
+	TeardownKindVPNGateway: true, TeardownKindVPNServer: true,
+}
+
+// DefaultTeardownConcurrency is the number of deletions Teardown runs in parallel, unless
+// configured otherwise.
+const DefaultTeardownConcurrency = 5
+
+// teardownDependencies maps each kind to the kinds whose resources must be deleted before it.
+var teardownDependencies = map[string][]string{
+	TeardownKindInstance:        {TeardownKindFloatingIP},
+	TeardownKindBareMetalServer: {TeardownKindFloatingIP},
+	TeardownKindSubnet: {TeardownKindInstance, TeardownKindBareMetalServer, TeardownKindLoadBalancer,
+		TeardownKindVPNGateway, TeardownKindVPNServer, TeardownKindEndpointGateway},
+	TeardownKindPublicGateway: {TeardownKindSubnet},
+	TeardownKindRoutingTable:  {TeardownKindSubnet},
+	TeardownKindAddressPrefix: {TeardownKindSubnet},
+	TeardownKindNetworkACL:    {TeardownKindSubnet},
+	TeardownKindSecurityGroup: {TeardownKindInstance, TeardownKindBareMetalServer, TeardownKindLoadBalancer,
+		TeardownKindVPNServer, TeardownKindEndpointGateway},
+	TeardownKindVPC: {TeardownKindAddressPrefix, TeardownKindBareMetalServer, TeardownKindEndpointGateway,
+		TeardownKindFloatingIP, TeardownKindInstance, TeardownKindLoadBalancer, TeardownKindNetworkACL,
+		TeardownKindPublicGateway, TeardownKindRoutingTable, TeardownKindSecurityGroup, TeardownKindSubnet,
+		TeardownKindVPNGateway, TeardownKindVPNServer},
+}
+
+// TeardownResource : A resource to delete with Teardown.
+type TeardownResource struct {
+	// The kind of the resource (one of the TeardownKind... constants). Resources of a kind are
+	// deleted after all resources of the kinds that depend on it.
+	Kind string
+
+	// The unique identifier of the resource.
+	ID string
+
+	// The name of the resource, for reporting.
+	Name string
+
+	// The IDs of other resources of the teardown that must be deleted before this one,
+	// in addition to the dependencies implied by Kind.
+	DependsOn []string
+
+	// Deletes the resource, usually with one of the generated Delete...WithContext operations.
+	// A 404 Not Found response is treated as success.
+	Delete func(ctx context.Context) (*core.DetailedResponse, error)
+
+	// If set, used after Delete to wait until the resource no longer exists (see WaitForDeletion).
+	Refresh WaitRefreshFunc
+}
+
+// TeardownDiscoveryOptions : The TeardownDiscoveryOptions struct configures
+// DiscoverTeardownResources.
+type TeardownDiscoveryOptions struct {
+	// The kind of the resources (one of the TeardownKind... constants).
+	Kind string
+
+	// Deletes the resource with the given ID, usually with one of the generated
+	// Delete...WithContext operations.
+	Delete func(ctx context.Context, id string) (*core.DetailedResponse, error)
+
+	// If set, returns the function used to wait until the resource with the given ID no longer
+	// exists (see TeardownResource.Refresh).
+	Refresh func(id string) WaitRefreshFunc
+
+	// The IDs of the resources to leave out, such as the default network ACL, routing table and
+	// security group of a VPC, which are deleted along with it.
+	Exclude []string
+}
+
+// DiscoverTeardownResources returns a TeardownResource for each resource retrieved with the
+// given List operation (see CollectionListFunc): usually a wrapper around one of the generated
+// List...WithContext operations, filtered by VPC. T is the type of the resources (for example
+// the Subnet model). The floating IPs bound to a public gateway are left out: they cannot be
+// deleted on their own, and are released along with their gateway. The resources of each kind
+// are discovered separately, and the results are passed together to Teardown:
+//
+//	subnets, err := vpcbetav1.DiscoverTeardownResources[vpcbetav1.Subnet](ctx, listSubnets,
+//		&vpcbetav1.TeardownDiscoveryOptions{Kind: vpcbetav1.TeardownKindSubnet, Delete: deleteSubnet})
+func DiscoverTeardownResources[T any](ctx context.Context, list CollectionListFunc, options *TeardownDiscoveryOptions) (resources []*TeardownResource, err error) {
+	if options == nil || options.Delete == nil {
+		err = fmt.Errorf("delete function cannot be nil")
+		return
+	}
+	if !teardownKinds[options.Kind] {
+		err = fmt.Errorf("unknown resource kind '%s'", options.Kind)
+		return
+	}
+	excluded := make(map[string]bool, len(options.Exclude))
+	for _, id := range options.Exclude {
+		excluded[id] = true
+	}
+	CollectionItems[T](ctx, list, nil)(func(item T, itemErr error) bool {
+		var resource *TeardownResource
+		if err = itemErr; err == nil {
+			if resource, err = teardownResourceOf(item, options); resource != nil && !excluded[resource.ID] {
+				resources = append(resources, resource)
+			}
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// teardownResourceOf returns the TeardownResource of a model, or nil if the model is deleted
+// along with another resource.
+func teardownResourceOf(model interface{}, options *TeardownDiscoveryOptions) (resource *TeardownResource, err error) {
+	object, err := ToJSONObject(model)
+	if err != nil {
+		return
+	}
+	id, _ := object["id"].(string)
+	if id == "" {
+		err = fmt.Errorf("%T has no id", model)
+		return
+	}
+	if target, ok := object["target"].(map[string]interface{}); ok && options.Kind == TeardownKindFloatingIP &&
+		target["resource_type"] == TeardownKindPublicGateway {
+		return
+	}
+	resource = &TeardownResource{
+		Kind: options.Kind,
+		ID:   id,
+		Delete: func(ctx context.Context) (*core.DetailedResponse, error) {
+			return options.Delete(ctx, id)
+		},
+	}
+	resource.Name, _ = object["name"].(string)
+	if options.Refresh != nil {
+		resource.Refresh = options.Refresh(id)
+	}
+	return
+}
+
+// TeardownOptions : The TeardownOptions struct configures Teardown.
+type TeardownOptions struct {
+	// If true, nothing is deleted: the report only lists the resources and their stage.
+	DryRun bool
+
+	// The number of deletions to run in parallel. If not set, DefaultTeardownConcurrency is used.
+	Concurrency int
+
+	// The options used to wait for each deletion.
+	Waiter *WaiterOptions
+}
+
+// TeardownResult : The outcome of the deletion of one resource.
+type TeardownResult struct {
+	// The resource.
+	Resource *TeardownResource
+
+	// The stage of the resource: resources of stage N only depend on resources of earlier stages,
+	// so all resources of a stage can be deleted in parallel.
+	Stage int
+
+	// Whether the resource was deleted (false in dry-run mode).
+	Deleted bool
+
+	// Whether the deletion was skipped because one of the resources it depends on could not be
+	// deleted or the context was done.
+	Skipped bool
+
+	// The error that prevented the deletion, if any.
+	Err error
+}
+
+// TeardownReport : The outcome of a teardown.
+type TeardownReport struct {
+	// The results, ordered by stage.
+	Results []*TeardownResult
+}
+
+// Failed returns the results of the resources that could not be deleted or were skipped.
+func (report *TeardownReport) Failed() (failed []*TeardownResult) {
+	for _, result := range report.Results {
+		if result.Err != nil || result.Skipped {
+			failed = append(failed, result)
+		}
+	}
+	return
+}
+
+// TeardownError : The error returned by Teardown when some resources could not be deleted.
+type TeardownError struct {
+	// The results of the resources that could not be deleted or were skipped.
+	Failed []*TeardownResult
+}
+
+// Error returns the error message.
+func (teardownErr *TeardownError) Error() string {
+	var msgs []string
+	skipped := 0
+	for _, result := range teardownErr.Failed {
+		if result.Err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s %s: %s", result.Resource.Kind, result.Resource.ID, result.Err.Error()))
+		} else {
+			skipped++
+		}
+	}
+	return fmt.Sprintf("teardown failed for %d resource(s), %d skipped: %s", len(msgs), skipped, strings.Join(msgs, "; "))
+}
+
+// Teardown deletes a set of resources, typically everything attached to a VPC and the VPC
+// itself, in dependency order. Resources that do not depend on each other are deleted in
+// parallel, and each deletion is waited for (if the resource has a Refresh function) before
+// the resources depending on it are deleted. A resource is skipped if one of its dependencies
+// could not be deleted.
+//
+// The report lists every resource; if some of them could not be deleted, a *TeardownError
+// is returned along with the report.
+func Teardown(ctx context.Context, resources []*TeardownResource, options *TeardownOptions) (report *TeardownReport, err error) {
+	if options == nil {
+		options = &TeardownOptions{}
+	}
+	dependencies, err := teardownGraph(resources)
+	if err != nil {
+		return
+	}
+	stages, err := teardownStages(resources, dependencies)
+	if err != nil {
+		return
+	}
+
+	results := make(map[*TeardownResource]*TeardownResult, len(resources))
+	report = &TeardownReport{}
+	for stage, stageResources := range stages {
+		for _, resource := range stageResources {
+			result := &TeardownResult{Resource: resource, Stage: stage}
+			results[resource] = result
+			report.Results = append(report.Results, result)
+		}
+	}
+	if options.DryRun {
+		return
+	}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultTeardownConcurrency
+	}
+	semaphore := make(chan struct{}, concurrency)
+	done := make(map[*TeardownResource]chan struct{}, len(resources))
+	for _, resource := range resources {
+		done[resource] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, resource := range resources {
+		wg.Add(1)
+		go func(resource *TeardownResource) {
+			defer wg.Done()
+			defer close(done[resource])
+			result := results[resource]
+
+			for _, dependency := range dependencies[resource] {
+				<-done[dependency]
+				if !results[dependency].Deleted {
+					result.Skipped = true
+					return
+				}
+			}
+
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				result.Skipped = true
+				return
+			}
+			defer func() { <-semaphore }()
+
+			result.Err = deleteTeardownResource(ctx, resource, options.Waiter)
+			result.Deleted = result.Err == nil
+		}(resource)
+	}
+	wg.Wait()
+
+	if failed := report.Failed(); len(failed) > 0 {
+		err = &TeardownError{Failed: failed}
+	}
+	return
+}
+
+// deleteTeardownResource deletes one resource and waits for the deletion to complete.
+func deleteTeardownResource(ctx context.Context, resource *TeardownResource, waiter *WaiterOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	response, err := resource.Delete(ctx)
+	if err = NewAPIError(response, err); err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if resource.Refresh != nil {
+		return WaitForDeletion(ctx, resource.Refresh, waiter)
+	}
+	return nil
+}
+
+// teardownGraph returns, for each resource, the resources that must be deleted before it.
+func teardownGraph(resources []*TeardownResource) (dependencies map[*TeardownResource][]*TeardownResource, err error) {
+	byID := make(map[string]*TeardownResource, len(resources))
+	byKind := make(map[string][]*TeardownResource)
+	for _, resource := range resources {
+		if resource == nil || resource.ID == "" || resource.Delete == nil {
+			err = fmt.Errorf("every resource must have an ID and a Delete function")
+			return
+		}
+		if !teardownKinds[resource.Kind] {
+			err = fmt.Errorf("resource '%s' has unknown kind '%s'", resource.ID, resource.Kind)
+			return
+		}
+		if byID[resource.ID] != nil {
+			err = fmt.Errorf("resource '%s' is listed more than once", resource.ID)
+			return
+		}
+		byID[resource.ID] = resource
+		byKind[resource.Kind] = append(byKind[resource.Kind], resource)
+	}
+
+	dependencies = make(map[*TeardownResource][]*TeardownResource, len(resources))
+	for _, resource := range resources {
+		for _, kind := range teardownDependencies[resource.Kind] {
+			dependencies[resource] = append(dependencies[resource], byKind[kind]...)
+		}
+		for _, id := range resource.DependsOn {
+			dependency := byID[id]
+			if dependency == nil {
+				err = fmt.Errorf("resource '%s' depends on unknown resource '%s'", resource.ID, id)
+				return
+			}
+			dependencies[resource] = append(dependencies[resource], dependency)
+		}
+	}
+	return
+}
+
+// teardownStages groups the resources into stages, in the order they can be deleted.
+func teardownStages(resources []*TeardownResource, dependencies map[*TeardownResource][]*TeardownResource) (stages [][]*TeardownResource, err error) {
+	stageOf := make(map[*TeardownResource]int, len(resources))
+	for len(stageOf) < len(resources) {
+		var stage []*TeardownResource
+		for _, resource := range resources {
+			if _, ok := stageOf[resource]; ok {
+				continue
+			}
+			ready := true
+			for _, dependency := range dependencies[resource] {
+				if _, ok := stageOf[dependency]; !ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				stage = append(stage, resource)
+			}
+		}
+		if len(stage) == 0 {
+			err = fmt.Errorf("the resources have circular dependencies")
+			return
+		}
+		for _, resource := range stage {
+			stageOf[resource] = len(stages)
+		}
+		stages = append(stages, stage)
+	}
+	return
+}
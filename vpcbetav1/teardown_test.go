@@ -0,0 +1,280 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testTeardownCollection mirrors the shape of the generated ...Collection models.
+type testTeardownCollection struct {
+	Items []testReference `json:"items"`
+}
+
+// testFloatingIP and testFloatingIPCollection mirror the shape of the generated models.
+type testFloatingIP struct {
+	ID     *string                `json:"id" validate:"required"`
+	Target map[string]interface{} `json:"target,omitempty"`
+}
+
+type testFloatingIPCollection struct {
+	FloatingIps []testFloatingIP `json:"floating_ips"`
+}
+
+var _ = Describe(`Teardown`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+
+	// fakeResource returns a TeardownResource that deletes the resource at the given path
+	// of the fake server.
+	fakeResource := func(kind string, path string) *vpcbetav1.TeardownResource {
+		return &vpcbetav1.TeardownResource{
+			Kind: kind,
+			ID:   path,
+			Delete: func(ctx context.Context) (*core.DetailedResponse, error) {
+				_, response, err := invoke(service, core.DELETE, path, nil)
+				return response, err
+			},
+			Refresh: func(ctx context.Context) (string, *core.DetailedResponse, error) {
+				result, response, err := invoke(service, core.GET, path, nil)
+				status, _ := result["status"].(string)
+				return status, response, err
+			},
+		}
+	}
+	create := func(path string, body map[string]interface{}) map[string]interface{} {
+		result, _, err := invoke(service, core.POST, path, body)
+		Expect(err).To(BeNil())
+		return result
+	}
+	waiter := &vpcbetav1.WaiterOptions{MinDelay: time.Millisecond}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		service = newTestService(server.ServiceURL())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Deletes a VPC and its resources in dependency order`, func() {
+		vpc := create("/vpcs", map[string]interface{}{"name": "my-vpc"})
+		vpcRef := map[string]interface{}{"id": vpc["id"]}
+		prefix := create(fmt.Sprintf("/vpcs/%s/address_prefixes", vpc["id"]), map[string]interface{}{
+			"cidr": "10.0.0.0/16", "zone": map[string]interface{}{"name": "us-south-1"},
+		})
+		subnet := create("/subnets", map[string]interface{}{"vpc": vpcRef, "ipv4_cidr_block": "10.0.1.0/24"})
+		sg := create("/security_groups", map[string]interface{}{"vpc": vpcRef})
+		instance := create("/instances", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "bx2-2x8"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+			"primary_network_interface": map[string]interface{}{
+				"subnet":          map[string]interface{}{"id": subnet["id"]},
+				"security_groups": []interface{}{map[string]interface{}{"id": sg["id"]}},
+			},
+		})
+
+		resources := []*vpcbetav1.TeardownResource{
+			fakeResource(vpcbetav1.TeardownKindVPC, fmt.Sprintf("/vpcs/%s", vpc["id"])),
+			fakeResource(vpcbetav1.TeardownKindSubnet, fmt.Sprintf("/subnets/%s", subnet["id"])),
+			fakeResource(vpcbetav1.TeardownKindSecurityGroup, fmt.Sprintf("/security_groups/%s", sg["id"])),
+			fakeResource(vpcbetav1.TeardownKindAddressPrefix, fmt.Sprintf("/vpcs/%s/address_prefixes/%s", vpc["id"], prefix["id"])),
+			fakeResource(vpcbetav1.TeardownKindInstance, fmt.Sprintf("/instances/%s", instance["id"])),
+		}
+
+		report, err := vpcbetav1.Teardown(context.Background(), resources, &vpcbetav1.TeardownOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(report.Results).To(HaveLen(5))
+		stages := map[string]int{}
+		for _, result := range report.Results {
+			Expect(result.Deleted).To(BeFalse())
+			stages[result.Resource.Kind] = result.Stage
+		}
+		Expect(stages).To(Equal(map[string]int{"instance": 0, "subnet": 1, "security_group": 1, "address_prefix": 2, "vpc": 3}))
+
+		report, err = vpcbetav1.Teardown(context.Background(), resources, &vpcbetav1.TeardownOptions{Waiter: waiter})
+		Expect(err).To(BeNil())
+		Expect(report.Failed()).To(BeEmpty())
+		for _, result := range report.Results {
+			Expect(result.Deleted).To(BeTrue())
+		}
+		_, response, err := invoke(service, core.GET, fmt.Sprintf("/vpcs/%s", vpc["id"]), nil)
+		Expect(vpcbetav1.IsNotFound(vpcbetav1.NewAPIError(response, err))).To(BeTrue())
+	})
+	It(`Reports partial failures and skips dependents`, func() {
+		vpc := create("/vpcs", map[string]interface{}{"name": "my-vpc"})
+		vpcRef := map[string]interface{}{"id": vpc["id"]}
+		subnet := create("/subnets", map[string]interface{}{"vpc": vpcRef, "ipv4_cidr_block": "10.0.1.0/24"})
+		create("/instances", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "bx2-2x8"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+			"primary_network_interface": map[string]interface{}{
+				"subnet": map[string]interface{}{"id": subnet["id"]},
+			},
+		})
+
+		// The instance is missing from the teardown, so the subnet cannot be deleted.
+		resources := []*vpcbetav1.TeardownResource{
+			fakeResource(vpcbetav1.TeardownKindSubnet, fmt.Sprintf("/subnets/%s", subnet["id"])),
+			fakeResource(vpcbetav1.TeardownKindVPC, fmt.Sprintf("/vpcs/%s", vpc["id"])),
+			fakeResource(vpcbetav1.TeardownKindFloatingIP, "/floating_ips/already-gone"),
+		}
+		report, err := vpcbetav1.Teardown(context.Background(), resources, &vpcbetav1.TeardownOptions{Waiter: waiter})
+		var teardownErr *vpcbetav1.TeardownError
+		Expect(errors.As(err, &teardownErr)).To(BeTrue())
+		Expect(teardownErr.Failed).To(HaveLen(2))
+		Expect(vpcbetav1.IsConflict(teardownErr.Failed[0].Err)).To(BeTrue())
+		Expect(teardownErr.Failed[1].Skipped).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("1 skipped"))
+		Expect(report.Results).To(HaveLen(3))
+		for _, result := range report.Results {
+			if result.Resource.Kind == vpcbetav1.TeardownKindFloatingIP {
+				Expect(result.Deleted).To(BeTrue())
+			}
+		}
+	})
+	It(`Invoke Teardown with error`, func() {
+		deleteNothing := func(ctx context.Context) (*core.DetailedResponse, error) { return nil, nil }
+		_, err := vpcbetav1.Teardown(context.Background(), []*vpcbetav1.TeardownResource{
+			{Kind: vpcbetav1.TeardownKindSubnet, ID: "a", DependsOn: []string{"b"}, Delete: deleteNothing},
+			{Kind: vpcbetav1.TeardownKindSubnet, ID: "b", DependsOn: []string{"a"}, Delete: deleteNothing},
+		}, nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = vpcbetav1.Teardown(context.Background(), []*vpcbetav1.TeardownResource{
+			{Kind: vpcbetav1.TeardownKindSubnet, ID: "a", DependsOn: []string{"c"}, Delete: deleteNothing},
+		}, nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = vpcbetav1.Teardown(context.Background(), []*vpcbetav1.TeardownResource{
+			{Kind: vpcbetav1.TeardownKindSubnet, ID: "a"},
+		}, nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = vpcbetav1.Teardown(context.Background(), []*vpcbetav1.TeardownResource{
+			{Kind: "subnets", ID: "a", Delete: deleteNothing},
+		}, nil)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("unknown kind 'subnets'"))
+	})
+	It(`Discovers the resources to delete`, func() {
+		vpc := create("/vpcs", map[string]interface{}{"name": "my-vpc"})
+		vpcRef := map[string]interface{}{"id": vpc["id"]}
+		subnet := create("/subnets", map[string]interface{}{"name": "my-subnet", "vpc": vpcRef, "ipv4_cidr_block": "10.0.1.0/24"})
+		other := create("/subnets", map[string]interface{}{"name": "other-subnet", "vpc": vpcRef, "ipv4_cidr_block": "10.0.2.0/24"})
+
+		// list returns a List operation of the collection at the given path.
+		list := func(path string, property string) vpcbetav1.CollectionListFunc {
+			return func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+				result, response, err := invoke(service, core.GET, path, nil)
+				if err != nil {
+					return nil, response, err
+				}
+				collection := &testTeardownCollection{}
+				for _, item := range result[property].([]interface{}) {
+					object := item.(map[string]interface{})
+					name, _ := object["name"].(string)
+					collection.Items = append(collection.Items, testReference{ID: core.StringPtr(object["id"].(string)), Name: &name})
+				}
+				return collection, response, nil
+			}
+		}
+		deleteAt := func(collection string) func(ctx context.Context, id string) (*core.DetailedResponse, error) {
+			return func(ctx context.Context, id string) (*core.DetailedResponse, error) {
+				_, response, err := invoke(service, core.DELETE, collection+"/"+id, nil)
+				return response, err
+			}
+		}
+
+		subnets, err := vpcbetav1.DiscoverTeardownResources[testReference](context.Background(), list("/subnets", "subnets"),
+			&vpcbetav1.TeardownDiscoveryOptions{Kind: vpcbetav1.TeardownKindSubnet, Delete: deleteAt("/subnets"), Exclude: []string{other["id"].(string)}})
+		Expect(err).To(BeNil())
+		Expect(subnets).To(HaveLen(1))
+		Expect(subnets[0].ID).To(Equal(subnet["id"]))
+		Expect(subnets[0].Name).To(Equal("my-subnet"))
+		vpcs, err := vpcbetav1.DiscoverTeardownResources[testReference](context.Background(), list("/vpcs", "vpcs"),
+			&vpcbetav1.TeardownDiscoveryOptions{Kind: vpcbetav1.TeardownKindVPC, Delete: deleteAt("/vpcs")})
+		Expect(err).To(BeNil())
+		Expect(vpcs).To(HaveLen(1))
+
+		report, err := vpcbetav1.Teardown(context.Background(), append(subnets, vpcs...), &vpcbetav1.TeardownOptions{DryRun: true})
+		Expect(err).To(BeNil())
+		Expect(report.Results[0].Resource.Kind).To(Equal(vpcbetav1.TeardownKindSubnet))
+		Expect(report.Results[1].Resource.Kind).To(Equal(vpcbetav1.TeardownKindVPC))
+
+		_, err = vpcbetav1.Teardown(context.Background(), subnets, nil)
+		Expect(err).To(BeNil())
+		_, response, err := invoke(service, core.GET, fmt.Sprintf("/subnets/%s", subnet["id"]), nil)
+		Expect(vpcbetav1.IsNotFound(vpcbetav1.NewAPIError(response, err))).To(BeTrue())
+
+		_, err = vpcbetav1.DiscoverTeardownResources[testReference](context.Background(), list("/vpcs", "vpcs"),
+			&vpcbetav1.TeardownDiscoveryOptions{Kind: "vpcs", Delete: deleteAt("/vpcs")})
+		Expect(err).ToNot(BeNil())
+		_, err = vpcbetav1.DiscoverTeardownResources[testReference](context.Background(), list("/vpcs", "vpcs"), nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Deletes public gateways with their floating IPs`, func() {
+		// The floating IP of the gateway cannot be deleted on its own, and is released with it.
+		existing := map[string]bool{"vpc": true, "gateway": true, "gateway-ip": true, "instance-ip": true}
+		remove := func(ctx context.Context, id string) (*core.DetailedResponse, error) {
+			if (id == "gateway-ip" || id == "vpc") && existing["gateway"] {
+				return &core.DetailedResponse{StatusCode: http.StatusConflict}, fmt.Errorf("%s is in use", id)
+			}
+			if id == "gateway" {
+				delete(existing, "gateway-ip")
+			}
+			delete(existing, id)
+			return &core.DetailedResponse{StatusCode: http.StatusNoContent}, nil
+		}
+		removeFn := func(id string) func(ctx context.Context) (*core.DetailedResponse, error) {
+			return func(ctx context.Context) (*core.DetailedResponse, error) {
+				return remove(ctx, id)
+			}
+		}
+		listFloatingIPs := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+			return &testFloatingIPCollection{FloatingIps: []testFloatingIP{
+				{ID: core.StringPtr("gateway-ip"), Target: map[string]interface{}{"id": "gateway", "resource_type": "public_gateway"}},
+				{ID: core.StringPtr("instance-ip")},
+			}}, nil, nil
+		}
+
+		floatingIPs, err := vpcbetav1.DiscoverTeardownResources[testFloatingIP](context.Background(), listFloatingIPs,
+			&vpcbetav1.TeardownDiscoveryOptions{Kind: vpcbetav1.TeardownKindFloatingIP, Delete: remove})
+		Expect(err).To(BeNil())
+		Expect(floatingIPs).To(HaveLen(1))
+		Expect(floatingIPs[0].ID).To(Equal("instance-ip"))
+
+		resources := append(floatingIPs,
+			&vpcbetav1.TeardownResource{Kind: vpcbetav1.TeardownKindVPC, ID: "vpc", Delete: removeFn("vpc")},
+			&vpcbetav1.TeardownResource{Kind: vpcbetav1.TeardownKindPublicGateway, ID: "gateway", Delete: removeFn("gateway")},
+		)
+		report, err := vpcbetav1.Teardown(context.Background(), resources, &vpcbetav1.TeardownOptions{Waiter: waiter})
+		Expect(err).To(BeNil())
+		Expect(report.Failed()).To(BeEmpty())
+		Expect(existing).To(BeEmpty())
+	})
+})
@@ -0,0 +1,385 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The formats of string properties checked by ValidateModel.
+const (
+	formatCIDR       = "cidr"
+	formatIPv4       = "ipv4"
+	formatIPv4OrCIDR = "ipv4_or_cidr"
+)
+
+// propertyConstraint is the set of schema constraints of a property.
+type propertyConstraint struct {
+	// The pattern that string values must match.
+	Pattern *regexp.Regexp
+
+	// The minimum and maximum lengths of string values. Ignored if zero.
+	MinLength int
+	MaxLength int
+
+	// The minimum and maximum of integer values.
+	Minimum *int64
+	Maximum *int64
+
+	// The allowed string values.
+	Enum []string
+
+	// The format of string values (formatCIDR, formatIPv4 or formatIPv4OrCIDR).
+	Format string
+}
+
+// namePattern is the pattern of resource names.
+var namePattern = regexp.MustCompile(`^-?([a-z]|[a-z][-a-z0-9]*[a-z0-9])$`)
+
+// nameConstraint is the constraint of resource names.
+var nameConstraint = &propertyConstraint{Pattern: namePattern, MinLength: 1, MaxLength: 63}
+
+// int64Ptr returns a pointer to an int64 literal.
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+// portConstraint is the constraint of TCP and UDP ports.
+var portConstraint = &propertyConstraint{Minimum: int64Ptr(1), Maximum: int64Ptr(65535)}
+
+// propertyConstraints are the constraints checked by ValidateModel, keyed by property name.
+// They apply to properties of that name at any depth of a model, so they are limited to the
+// properties that have the same meaning in every schema of the API.
+var propertyConstraints = map[string]*propertyConstraint{
+	"cidr_block":               {Format: formatCIDR},
+	"ipv4_cidr_block":          {Format: formatCIDR},
+	"iops":                     {Minimum: int64Ptr(100), Maximum: int64Ptr(48000)},
+	"capacity":                 {Minimum: int64Ptr(10), Maximum: int64Ptr(32000)},
+	"total_ipv4_address_count": {Minimum: int64Ptr(8), Maximum: int64Ptr(65536)},
+}
+
+// resourceConstraints are the constraints checked by ValidateModel on the top-level properties
+// of a model only: the properties of the resource it creates or updates, not those of the
+// resources it references (such as the name of a `profile`). The prototypes and patches nested
+// in options models (such as the `SubnetPrototype` of CreateSubnetOptions) are models too.
+var resourceConstraints = map[string]*propertyConstraint{
+	"name":    nameConstraint,
+	"cidr":    {Format: formatCIDR},
+	"address": {Format: formatIPv4},
+}
+
+// ruleConstraints are the constraints checked by ValidateModel on security group and network
+// ACL rules: the top-level properties of the SecurityGroupRule... and NetworkACLRule... models,
+// and the properties of the objects with a `direction` (such as the `rules` of security group
+// and network ACL prototypes).
+var ruleConstraints = map[string]*propertyConstraint{
+	"name":                 nameConstraint,
+	"source":               {Format: formatIPv4OrCIDR},
+	"destination":          {Format: formatIPv4OrCIDR},
+	"port_min":             portConstraint,
+	"port_max":             portConstraint,
+	"source_port_min":      portConstraint,
+	"source_port_max":      portConstraint,
+	"destination_port_min": portConstraint,
+	"destination_port_max": portConstraint,
+	"direction":            {Enum: []string{"inbound", "outbound"}},
+	"ip_version":           {Enum: []string{"ipv4"}},
+	"protocol":             {Enum: []string{"all", "any", "icmp", "icmp_tcp_udp", "tcp", "udp"}},
+	"action":               {Enum: []string{"allow", "deny"}},
+}
+
+// ruleModelPattern matches the type names of the rule models, such as SecurityGroupRulePatch.
+var ruleModelPattern = regexp.MustCompile(`(SecurityGroupRule|NetworkACLRule)`)
+
+// nestedModelPattern matches the properties of options models holding a prototype or patch
+// model, such as `SubnetPrototype` or `Subnet_patch`.
+var nestedModelPattern = regexp.MustCompile(`(Prototype|_patch)$`)
+
+// propertyRanges are the pairs of properties of rules whose first value must not be greater
+// than the second, when both are set.
+var propertyRanges = [][2]string{
+	{"port_min", "port_max"},
+	{"source_port_min", "source_port_max"},
+	{"destination_port_min", "destination_port_max"},
+}
+
+// referenceIdentities are the properties holding references to other resources, with the
+// properties that identify a resource: a reference must set one of them.
+var referenceIdentities = map[string][]string{
+	"image":           {"id", "crn", "href"},
+	"keys":            {"id", "crn", "fingerprint", "href"},
+	"network_acl":     {"id", "crn", "href"},
+	"profile":         {"name", "href"},
+	"public_gateway":  {"id", "crn", "href"},
+	"resource_group":  {"id"},
+	"routing_table":   {"id", "crn", "href"},
+	"security_groups": {"id", "crn", "href"},
+	"vpc":             {"id", "crn", "href"},
+	"zone":            {"name", "href"},
+}
+
+// FieldError : A validation error of a field of a model.
+type FieldError struct {
+	// The JSON path of the field (for example `rules[0].port_min`).
+	Field string
+
+	// The invalid value, if any.
+	Value interface{}
+
+	// The error message.
+	Message string
+}
+
+// Error returns the error message.
+func (fieldErr *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message)
+}
+
+// ValidationError : The error returned by ValidateModel, listing the invalid fields.
+type ValidationError struct {
+	// The errors, ordered by field.
+	Errors []*FieldError
+}
+
+// Error returns the error message.
+func (validationErr *ValidationError) Error() string {
+	msgs := make([]string, len(validationErr.Errors))
+	for i, fieldErr := range validationErr.Errors {
+		msgs[i] = fieldErr.Error()
+	}
+	return fmt.Sprintf("validation failed: %s", strings.Join(msgs, "; "))
+}
+
+// ValidateModel checks a prototype, patch or options model before it is sent, and returns a
+// *ValidationError listing all the fields that violate the constraints of the API schema:
+//
+//   - the required fields (tagged `validate:"required"`) of prototypes and options
+//   - CIDR blocks, and IOPS, capacity and address count ranges
+//   - the name pattern, CIDR block and address of the resource, including the prototypes and
+//     patches nested in options models
+//   - the addresses, ports and enumerations of rules, such as `port_min` not greater than
+//     `port_max`
+//   - references identifying a resource, such as a `vpc` reference with an `id`, `crn` or `href`
+//   - rule prototypes (with a `direction`) setting a `protocol`
+//
+// Required fields are not checked for patches (models whose type name ends with `Patch`).
+func ValidateModel(model interface{}) error {
+	value := reflect.ValueOf(model)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return &ValidationError{Errors: []*FieldError{{Message: "the model must not be nil"}}}
+		}
+		value = value.Elem()
+	}
+	typeName := value.Type().Name()
+	patch := strings.HasSuffix(typeName, "Patch")
+
+	var errs []*FieldError
+	if !patch {
+		errs = append(errs, validateRequired(value, "")...)
+	}
+	object, err := ToJSONObject(model)
+	if err != nil {
+		return err
+	}
+	errs = append(errs, validateObject(object, "", "", true, patch, ruleModelPattern.MatchString(typeName))...)
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return &ValidationError{Errors: errs}
+}
+
+// validateRequired returns the errors of the required fields of a struct that are not set.
+func validateRequired(value reflect.Value, path string) (errs []*FieldError) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			errs = append(errs, validateRequired(value.Index(i), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				name = field.Name
+			}
+			fieldPath := joinFieldPath(path, name)
+			fieldValue := value.Field(i)
+			if strings.Contains(field.Tag.Get("validate"), "required") && isEmptyValue(fieldValue) {
+				errs = append(errs, &FieldError{Field: fieldPath, Message: "is required"})
+				continue
+			}
+			errs = append(errs, validateRequired(fieldValue, fieldPath)...)
+		}
+	}
+	return
+}
+
+// isEmptyValue returns true if a required field is not set.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return value.IsNil()
+	case reflect.String:
+		return value.Len() == 0
+	}
+	return false
+}
+
+// validateObject returns the errors of the properties of a JSON object, at any depth. If root
+// is true, the object is a model (or the prototype or patch of an options model), and if rule
+// is true, it is a security group or network ACL rule.
+func validateObject(object map[string]interface{}, path string, property string, root bool, patch bool, rule bool) (errs []*FieldError) {
+	if identities, ok := referenceIdentities[property]; ok {
+		identified := false
+		for _, identity := range identities {
+			identified = identified || object[identity] != nil
+		}
+		if !identified {
+			errs = append(errs, &FieldError{Field: path,
+				Message: fmt.Sprintf("must identify a resource with one of %s", strings.Join(identities, ", "))})
+		}
+	}
+	if _, ok := object["direction"]; ok {
+		rule = true
+		if !patch && object["protocol"] == nil {
+			errs = append(errs, &FieldError{Field: joinFieldPath(path, "protocol"), Message: "is required"})
+		}
+	}
+	if rule {
+		for _, bounds := range propertyRanges {
+			minimum, minOK := object[bounds[0]].(float64)
+			maximum, maxOK := object[bounds[1]].(float64)
+			if minOK && maxOK && minimum > maximum {
+				errs = append(errs, &FieldError{Field: joinFieldPath(path, bounds[0]), Value: minimum,
+					Message: fmt.Sprintf("must not be greater than %s (%v)", bounds[1], maximum)})
+			}
+		}
+	}
+	constraintOf := func(name string) *propertyConstraint {
+		if constraint := ruleConstraints[name]; rule && constraint != nil {
+			return constraint
+		}
+		if constraint := resourceConstraints[name]; root && constraint != nil {
+			return constraint
+		}
+		return propertyConstraints[name]
+	}
+
+	for name, value := range object {
+		fieldPath := joinFieldPath(path, name)
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if path == "" && nestedModelPattern.MatchString(name) {
+				errs = append(errs, validateObject(value, fieldPath, name, true,
+					patch || strings.HasSuffix(name, "_patch"), ruleModelPattern.MatchString(name))...)
+				continue
+			}
+			errs = append(errs, validateObject(value, fieldPath, name, false, patch, false)...)
+		case []interface{}:
+			for i, item := range value {
+				itemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+				if itemObject, ok := item.(map[string]interface{}); ok {
+					errs = append(errs, validateObject(itemObject, itemPath, name, false, patch, false)...)
+				} else if constraint := constraintOf(name); constraint != nil {
+					errs = append(errs, validateValue(constraint, item, itemPath)...)
+				}
+			}
+		default:
+			if constraint := constraintOf(name); constraint != nil {
+				errs = append(errs, validateValue(constraint, value, fieldPath)...)
+			}
+		}
+	}
+	return
+}
+
+// validateValue returns the errors of a value that violates a constraint.
+func validateValue(constraint *propertyConstraint, value interface{}, path string) (errs []*FieldError) {
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: path, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+	switch value := value.(type) {
+	case string:
+		if constraint.MinLength > 0 && len(value) < constraint.MinLength {
+			fail("must be at least %d characters long", constraint.MinLength)
+		}
+		if constraint.MaxLength > 0 && len(value) > constraint.MaxLength {
+			fail("must be at most %d characters long", constraint.MaxLength)
+		}
+		if constraint.Pattern != nil && !constraint.Pattern.MatchString(value) {
+			fail("must match the pattern %s", constraint.Pattern.String())
+		}
+		if len(constraint.Enum) > 0 {
+			found := false
+			for _, allowed := range constraint.Enum {
+				found = found || value == allowed
+			}
+			if !found {
+				fail("must be one of %s", strings.Join(constraint.Enum, ", "))
+			}
+		}
+		switch constraint.Format {
+		case formatCIDR:
+			if prefix, err := netip.ParsePrefix(value); err != nil || prefix.Masked() != prefix {
+				fail("must be a CIDR block, such as 10.0.0.0/24")
+			}
+		case formatIPv4:
+			if addr, err := netip.ParseAddr(value); err != nil || !addr.Is4() {
+				fail("must be an IPv4 address")
+			}
+		case formatIPv4OrCIDR:
+			if addr, err := netip.ParseAddr(value); err == nil && addr.Is4() {
+				break
+			}
+			if prefix, err := netip.ParsePrefix(value); err != nil || prefix.Masked() != prefix {
+				fail("must be an IPv4 address or a CIDR block, such as 10.0.0.0/24")
+			}
+		}
+	case float64:
+		if constraint.Minimum != nil && value < float64(*constraint.Minimum) {
+			fail("must be at least %d", *constraint.Minimum)
+		}
+		if constraint.Maximum != nil && value > float64(*constraint.Maximum) {
+			fail("must be at most %d", *constraint.Maximum)
+		}
+	}
+	return
+}
+
+// joinFieldPath appends a property name to a JSON path.
+func joinFieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,189 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testSecurityGroupRulePrototype, testSecurityGroupPrototype, testSecurityGroupRulePatch and
+// testSecurityGroupPatch mirror the shape of the generated models.
+type testSecurityGroupRulePrototype struct {
+	Direction *string `json:"direction" validate:"required"`
+	Protocol  *string `json:"protocol,omitempty"`
+	PortMin   *int64  `json:"port_min,omitempty"`
+	PortMax   *int64  `json:"port_max,omitempty"`
+}
+
+type testSecurityGroupPrototype struct {
+	Name  *string                           `json:"name,omitempty"`
+	VPC   map[string]interface{}            `json:"vpc" validate:"required"`
+	Rules []*testSecurityGroupRulePrototype `json:"rules,omitempty"`
+}
+
+type testSecurityGroupRulePatch struct {
+	Protocol *string `json:"protocol,omitempty"`
+	PortMin  *int64  `json:"port_min,omitempty"`
+}
+
+type testSecurityGroupPatch struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// testSubnetPrototype, testCreateSubnetOptions and testUpdateSubnetOptions mirror the shape of
+// the generated options models, which hold the prototype or patch of the resource.
+type testSubnetPrototype struct {
+	Name          *string                `json:"name,omitempty"`
+	Ipv4CIDRBlock *string                `json:"ipv4_cidr_block,omitempty"`
+	VPC           map[string]interface{} `json:"vpc" validate:"required"`
+}
+
+type testCreateSubnetOptions struct {
+	SubnetPrototype *testSubnetPrototype `json:"SubnetPrototype" validate:"required"`
+	Headers         map[string]string
+}
+
+type testUpdateSubnetOptions struct {
+	ID          *string                `json:"id" validate:"required,ne="`
+	SubnetPatch map[string]interface{} `json:"Subnet_patch" validate:"required"`
+	Headers     map[string]string
+}
+
+// fieldsOf returns the fields of the errors of a *ValidationError.
+func fieldsOf(err error) (fields []string) {
+	Expect(err).To(BeAssignableToTypeOf(&vpcbetav1.ValidationError{}))
+	for _, fieldErr := range err.(*vpcbetav1.ValidationError).Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	return
+}
+
+var _ = Describe(`ValidateModel`, func() {
+	It(`Accepts valid models`, func() {
+		Expect(vpcbetav1.ValidateModel(&testSecurityGroupPrototype{
+			Name: core.StringPtr("my-security-group"),
+			VPC:  map[string]interface{}{"id": "vpc-1"},
+			Rules: []*testSecurityGroupRulePrototype{{
+				Direction: core.StringPtr("inbound"),
+				Protocol:  core.StringPtr("tcp"),
+				PortMin:   core.Int64Ptr(22),
+				PortMax:   core.Int64Ptr(22),
+			}},
+		})).To(Succeed())
+		Expect(vpcbetav1.ValidateModel(&testSecurityGroupPatch{})).To(Succeed())
+		Expect(vpcbetav1.ValidateModel(map[string]interface{}{
+			"ipv4_cidr_block": "10.0.0.0/24",
+			"zone":            map[string]interface{}{"name": "us-south-1"},
+		})).To(Succeed())
+	})
+	It(`Returns aggregated field errors`, func() {
+		err := vpcbetav1.ValidateModel(&testSecurityGroupPrototype{
+			Name: core.StringPtr("My_Group"),
+			VPC:  map[string]interface{}{"name": "my-vpc"},
+			Rules: []*testSecurityGroupRulePrototype{
+				{Direction: core.StringPtr("sideways"), Protocol: core.StringPtr("tcp"), PortMin: core.Int64Ptr(443), PortMax: core.Int64Ptr(80)},
+				{Direction: core.StringPtr("inbound"), PortMax: core.Int64Ptr(70000)},
+				{Protocol: core.StringPtr("udp")},
+			},
+		})
+		Expect(fieldsOf(err)).To(Equal([]string{
+			"name",
+			"rules[0].direction",
+			"rules[0].port_min",
+			"rules[1].port_max",
+			"rules[1].protocol",
+			"rules[2].direction",
+			"vpc",
+		}))
+		Expect(err.Error()).To(HavePrefix("validation failed: name: must match the pattern"))
+		Expect(err.Error()).To(ContainSubstring("rules[0].port_min: must not be greater than port_max (80)"))
+		Expect(err.Error()).To(ContainSubstring("vpc: must identify a resource with one of id, crn, href"))
+	})
+	It(`Checks formats, lengths and ranges`, func() {
+		err := vpcbetav1.ValidateModel(map[string]interface{}{
+			"name":            "a-name-that-is-much-too-long-to-be-accepted-by-the-vpc-api-at-all",
+			"ipv4_cidr_block": "10.0.0.1/24",
+			"address":         "fe80::1",
+			"iops":            50,
+			"rules": []interface{}{map[string]interface{}{"direction": "inbound", "protocol": "all",
+				"action": "allow", "source": "10.0.0.0/33", "destination": "0.0.0.0/0"}},
+		})
+		Expect(fieldsOf(err)).To(Equal([]string{"address", "iops", "ipv4_cidr_block", "name", "rules[0].source"}))
+		Expect(err.(*vpcbetav1.ValidationError).Errors[1].Value).To(Equal(float64(50)))
+	})
+	It(`Accepts the addresses of network ACL rules`, func() {
+		Expect(vpcbetav1.ValidateModel(map[string]interface{}{
+			"name": "my-network-acl",
+			"rules": []interface{}{map[string]interface{}{"direction": "inbound", "protocol": "all",
+				"action": "deny", "source": "10.0.0.5", "destination": "10.240.0.0/24"}},
+		})).To(Succeed())
+	})
+	It(`Scopes constraints to their models`, func() {
+		// Names of referenced resources are not resource names.
+		Expect(vpcbetav1.ValidateModel(map[string]interface{}{
+			"name":     "my-volume",
+			"capacity": 100,
+			"profile":  map[string]interface{}{"name": "10iops-tier"},
+			"zone":     map[string]interface{}{"name": "us-south-1"},
+		})).To(Succeed())
+		// Load balancer listeners, pools and policies are not rules.
+		Expect(vpcbetav1.ValidateModel(map[string]interface{}{
+			"protocol": "http", "port": 80,
+			"policies": []interface{}{map[string]interface{}{"name": "my-policy", "action": "forward", "priority": 1}},
+		})).To(Succeed())
+		Expect(vpcbetav1.ValidateModel(map[string]interface{}{
+			"name": "my-pool", "protocol": "https", "algorithm": "round_robin",
+		})).To(Succeed())
+		// Rule models are rules, even without a direction.
+		err := vpcbetav1.ValidateModel(&testSecurityGroupRulePatch{Protocol: core.StringPtr("http")})
+		Expect(fieldsOf(err)).To(Equal([]string{"protocol"}))
+	})
+	It(`Validates the prototypes and patches of options`, func() {
+		Expect(vpcbetav1.ValidateModel(&testCreateSubnetOptions{
+			SubnetPrototype: &testSubnetPrototype{
+				Name:          core.StringPtr("my-subnet"),
+				Ipv4CIDRBlock: core.StringPtr("10.0.0.0/24"),
+				VPC:           map[string]interface{}{"id": "vpc-1"},
+			},
+		})).To(Succeed())
+		err := vpcbetav1.ValidateModel(&testCreateSubnetOptions{
+			SubnetPrototype: &testSubnetPrototype{
+				Name: core.StringPtr("My_Subnet"),
+				VPC:  map[string]interface{}{"name": "my-vpc"},
+			},
+		})
+		Expect(fieldsOf(err)).To(Equal([]string{"SubnetPrototype.name", "SubnetPrototype.vpc"}))
+		Expect(fieldsOf(vpcbetav1.ValidateModel(&testCreateSubnetOptions{}))).To(Equal([]string{"SubnetPrototype"}))
+
+		err = vpcbetav1.ValidateModel(&testUpdateSubnetOptions{
+			ID:          core.StringPtr("subnet-1"),
+			SubnetPatch: map[string]interface{}{"name": "My_Subnet", "network_acl": map[string]interface{}{"name": "My_ACL"}},
+		})
+		Expect(fieldsOf(err)).To(Equal([]string{"Subnet_patch.name", "Subnet_patch.network_acl"}))
+	})
+	It(`Does not require fields of patches`, func() {
+		err := vpcbetav1.ValidateModel(&testSecurityGroupPatch{Name: core.StringPtr("-")})
+		Expect(fieldsOf(err)).To(Equal([]string{"name"}))
+	})
+	It(`Rejects nil models`, func() {
+		var model *testSecurityGroupPrototype
+		Expect(vpcbetav1.ValidateModel(model)).ToNot(Succeed())
+	})
+})
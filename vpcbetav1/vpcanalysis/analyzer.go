@@ -0,0 +1,400 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcanalysis
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+)
+
+// DefaultSourcePort is the source port of TCP and UDP queries, unless configured otherwise: the
+// first ephemeral port.
+const DefaultSourcePort = 49152
+
+// The directions of the packets of a query.
+const (
+	DirectionRequest  = "request"
+	DirectionResponse = "response"
+)
+
+// The kinds of the steps of a trace.
+const (
+	KindNetworkACL    = "network_acl"
+	KindSecurityGroup = "security_group"
+)
+
+// Query : A reachability query: can a packet go from the source to the destination, and its
+// response come back?
+type Query struct {
+	// The source and destination: the ID or name of an interface of the model, or an IPv4
+	// address. Addresses outside of the subnets of the model are external.
+	Source      string
+	Destination string
+
+	// The protocol of the packet: `tcp`, `udp` or `icmp`.
+	Protocol string
+
+	// The destination port of TCP and UDP packets.
+	Port int64
+
+	// The source port of TCP and UDP packets. If not set, DefaultSourcePort is used.
+	SourcePort int64
+
+	// The type and code of ICMP packets. If Type is not set, an echo request (type 8) is used;
+	// the response of an echo request is an echo reply (type 0).
+	Type *int64
+	Code *int64
+}
+
+// Step : The evaluation of the rules of a network ACL or of the security groups of an
+// interface, for one packet.
+type Step struct {
+	// The packet: DirectionRequest or DirectionResponse.
+	Direction string
+
+	// The kind of rules: KindNetworkACL or KindSecurityGroup.
+	Kind string
+
+	// The direction of the rules evaluated: `inbound` or `outbound`.
+	RuleDirection string
+
+	// The network ACL, or the security group of the rule that allowed the packet.
+	ResourceID   string
+	ResourceName string
+
+	// The rule that allowed or denied the packet, nil if no rule matched.
+	NetworkACLRule    *vpcbetav1.NormalizedNetworkACLRule
+	SecurityGroupRule *vpcbetav1.NormalizedSecurityGroupRule
+
+	// Whether the packet is allowed.
+	Allowed bool
+
+	// A description of the outcome.
+	Reason string
+}
+
+// String returns a description of the step.
+func (step *Step) String() string {
+	outcome := "denied"
+	if step.Allowed {
+		outcome = "allowed"
+	}
+	return fmt.Sprintf("%s %s %s: %s (%s)", step.Direction, step.RuleDirection,
+		strings.ReplaceAll(step.Kind, "_", " "), outcome, step.Reason)
+}
+
+// Result : The answer to a reachability query.
+type Result struct {
+	// Whether the packet and its response are allowed by every step.
+	Reachable bool
+
+	// The steps of the packet, then of its response, in the order they are evaluated.
+	Trace []*Step
+}
+
+// String returns a description of the result, with one step per line.
+func (result *Result) String() string {
+	lines := []string{"unreachable"}
+	if result.Reachable {
+		lines[0] = "reachable"
+	}
+	for _, step := range result.Trace {
+		lines = append(lines, "  "+step.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// endpoint is the source or destination of a query.
+type endpoint struct {
+	address netip.Addr
+	iface   *Interface
+	subnet  *Subnet
+}
+
+// packet is a packet evaluated against rules.
+type packet struct {
+	protocol         string
+	source           netip.Addr
+	destination      netip.Addr
+	sourcePort       int64
+	destinationPort  int64
+	icmpType         *int64
+	icmpCode         *int64
+	sourceIface      *Interface
+	destinationIface *Interface
+}
+
+// Analyze answers a reachability query. The packet is evaluated against the outbound
+// security group rules of the source interface, the outbound rules of the network ACL of the
+// source subnet, the inbound rules of the network ACL of the destination subnet and the
+// inbound security group rules of the destination interface; its response against the
+// network ACLs only, as security groups are stateful. Network ACLs do not apply to the
+// traffic within a subnet, and no rules apply to external endpoints.
+func (model *Model) Analyze(query *Query) (result *Result, err error) {
+	request := &packet{protocol: strings.ToLower(query.Protocol)}
+	switch request.protocol {
+	case "tcp", "udp":
+		if query.Port < 1 || query.Port > 65535 {
+			return nil, fmt.Errorf("port must be between 1 and 65535, not %d", query.Port)
+		}
+		request.sourcePort, request.destinationPort = query.SourcePort, query.Port
+		if request.sourcePort == 0 {
+			request.sourcePort = DefaultSourcePort
+		}
+	case "icmp":
+		request.icmpType, request.icmpCode = query.Type, query.Code
+		if request.icmpType == nil {
+			request.icmpType = core.Int64Ptr(8)
+		}
+	default:
+		return nil, fmt.Errorf("protocol must be tcp, udp or icmp, not %q", query.Protocol)
+	}
+	source, err := model.resolve(query.Source)
+	if err != nil {
+		return
+	}
+	destination, err := model.resolve(query.Destination)
+	if err != nil {
+		return
+	}
+	request.source, request.destination = source.address, destination.address
+	request.sourceIface, request.destinationIface = source.iface, destination.iface
+
+	response := &packet{
+		protocol:         request.protocol,
+		source:           request.destination,
+		destination:      request.source,
+		sourcePort:       request.destinationPort,
+		destinationPort:  request.sourcePort,
+		icmpType:         request.icmpType,
+		icmpCode:         request.icmpCode,
+		sourceIface:      request.destinationIface,
+		destinationIface: request.sourceIface,
+	}
+	if request.protocol == "icmp" && *request.icmpType == 8 {
+		response.icmpType = core.Int64Ptr(0)
+	}
+
+	// Network ACLs apply to the traffic leaving or entering a subnet only.
+	crossesSubnets := source.subnet == nil || destination.subnet == nil || source.subnet != destination.subnet
+	result = &Result{Reachable: true}
+	add := func(step *Step, stepErr error) {
+		if stepErr != nil && err == nil {
+			err = stepErr
+		}
+		if step != nil {
+			result.Trace = append(result.Trace, step)
+			result.Reachable = result.Reachable && step.Allowed
+		}
+	}
+	add(model.evaluateSecurityGroups(DirectionRequest, "outbound", source.iface, request), nil)
+	if crossesSubnets {
+		add(model.evaluateNetworkACL(DirectionRequest, "outbound", source.subnet, request))
+		add(model.evaluateNetworkACL(DirectionRequest, "inbound", destination.subnet, request))
+	}
+	add(model.evaluateSecurityGroups(DirectionRequest, "inbound", destination.iface, request), nil)
+	if crossesSubnets {
+		add(model.evaluateNetworkACL(DirectionResponse, "outbound", destination.subnet, response))
+		add(model.evaluateNetworkACL(DirectionResponse, "inbound", source.subnet, response))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// resolve returns the endpoint with the given interface ID or name, or address.
+func (model *Model) resolve(value string) (*endpoint, error) {
+	var iface *Interface
+	if iface = model.Interfaces[value]; iface == nil {
+		for _, candidate := range model.Interfaces {
+			if candidate.Name == value {
+				iface = candidate
+				break
+			}
+		}
+	}
+	if iface != nil {
+		if len(iface.Addresses) == 0 {
+			return nil, fmt.Errorf("interface %s has no address", value)
+		}
+		return &endpoint{address: iface.Addresses[0], iface: iface, subnet: model.Subnets[iface.SubnetID]}, nil
+	}
+
+	address, err := netip.ParseAddr(value)
+	if err != nil || !address.Is4() {
+		return nil, fmt.Errorf("%s is neither an interface of the model nor an IPv4 address", value)
+	}
+	result := &endpoint{address: address}
+	for _, candidate := range model.Interfaces {
+		for _, candidateAddress := range candidate.Addresses {
+			if candidateAddress == address {
+				result.iface = candidate
+				result.subnet = model.Subnets[candidate.SubnetID]
+				return result, nil
+			}
+		}
+	}
+	for _, subnet := range model.Subnets {
+		if subnet.CIDR.Contains(address) {
+			result.subnet = subnet
+		}
+	}
+	return result, nil
+}
+
+// evaluateSecurityGroups evaluates the rules of the security groups of an interface, if any.
+func (model *Model) evaluateSecurityGroups(direction string, ruleDirection string, iface *Interface, p *packet) *Step {
+	if iface == nil {
+		return nil
+	}
+	step := &Step{Direction: direction, Kind: KindSecurityGroup, RuleDirection: ruleDirection}
+	local, remote, remoteIface := p.source, p.destination, p.destinationIface
+	if ruleDirection == "inbound" {
+		local, remote, remoteIface = p.destination, p.source, p.sourceIface
+	}
+	var names []string
+	for _, id := range iface.SecurityGroupIDs {
+		sg := model.SecurityGroups[id]
+		if sg == nil {
+			names = append(names, id+" (not in the model)")
+			continue
+		}
+		names = append(names, sg.Name)
+		for _, rule := range sg.Rules {
+			if rule.Direction == ruleDirection && matchesSecurityGroupRule(rule, p) &&
+				endpointContains(rule.Local, local, nil, nil) &&
+				endpointContains(rule.Remote, remote, remoteIface, model.SecurityGroups) {
+				step.ResourceID, step.ResourceName = sg.ID, sg.Name
+				step.SecurityGroupRule = rule
+				step.Allowed = true
+				step.Reason = fmt.Sprintf("security group %s rule %s %s", sg.Name, rule.ID, rule.String())
+				return step
+			}
+		}
+	}
+	if len(names) == 0 {
+		step.Reason = fmt.Sprintf("interface %s has no security groups", iface.Name)
+	} else {
+		step.Reason = fmt.Sprintf("no %s rule of security groups %s allows the packet", ruleDirection, strings.Join(names, ", "))
+	}
+	return step
+}
+
+// matchesSecurityGroupRule returns true if the protocol, ports, type and code of a packet match
+// a security group rule. The ports of a rule are destination ports.
+func matchesSecurityGroupRule(rule *vpcbetav1.NormalizedSecurityGroupRule, p *packet) bool {
+	switch rule.Protocol {
+	case "all", "any", "icmp_tcp_udp":
+		return true
+	case "tcp", "udp":
+		return rule.Protocol == p.protocol && inRange(p.destinationPort, rule.PortMin, rule.PortMax)
+	case "icmp":
+		return p.protocol == "icmp" && matchesICMP(rule.Type, rule.Code, p)
+	}
+	return false
+}
+
+// endpointContains returns true if the address (of the given interface, if any) is within the
+// remote or local endpoint of a security group rule.
+func endpointContains(endpoint *vpcbetav1.SecurityGroupRuleEndpoint, address netip.Addr, iface *Interface, securityGroups map[string]*SecurityGroup) bool {
+	switch {
+	case endpoint.CIDRBlock != "":
+		return cidrContains(endpoint.CIDRBlock, address)
+	case endpoint.Address != "":
+		return endpoint.Address == address.String()
+	}
+	if iface == nil {
+		return false
+	}
+	for _, id := range iface.SecurityGroupIDs {
+		sg := securityGroups[id]
+		if sg == nil {
+			sg = &SecurityGroup{ID: id}
+		}
+		if endpoint.Matches(&vpcbetav1.SecurityGroupRuleEndpoint{ID: sg.ID, CRN: sg.CRN, Href: sg.Href, Name: sg.Name}) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateNetworkACL evaluates the rules of the network ACL of a subnet, if any, in order.
+func (model *Model) evaluateNetworkACL(direction string, ruleDirection string, subnet *Subnet, p *packet) (*Step, error) {
+	if subnet == nil {
+		return nil, nil
+	}
+	acl := model.NetworkACLs[subnet.NetworkACLID]
+	if acl == nil {
+		return nil, fmt.Errorf("network ACL %s of subnet %s is not in the model", subnet.NetworkACLID, subnet.Name)
+	}
+	step := &Step{Direction: direction, Kind: KindNetworkACL, RuleDirection: ruleDirection,
+		ResourceID: acl.ID, ResourceName: acl.Name}
+	for _, rule := range acl.Rules {
+		if rule.Direction == ruleDirection && matchesNetworkACLRule(rule, p) {
+			step.NetworkACLRule = rule
+			step.Allowed = rule.Action == "allow"
+			step.Reason = fmt.Sprintf("network ACL %s rule %s %s", acl.Name, rule.Name, rule.String())
+			return step, nil
+		}
+	}
+	step.Reason = fmt.Sprintf("no %s rule of network ACL %s matches the packet", ruleDirection, acl.Name)
+	return step, nil
+}
+
+// matchesNetworkACLRule returns true if the addresses, protocol, ports, type and code of a
+// packet match a network ACL rule.
+func matchesNetworkACLRule(rule *vpcbetav1.NormalizedNetworkACLRule, p *packet) bool {
+	if !cidrContains(rule.Source, p.source) || !cidrContains(rule.Destination, p.destination) {
+		return false
+	}
+	switch rule.Protocol {
+	case "all", "any", "icmp_tcp_udp":
+		return true
+	case "tcp", "udp":
+		return rule.Protocol == p.protocol && inRange(p.sourcePort, rule.SourcePortMin, rule.SourcePortMax) &&
+			inRange(p.destinationPort, rule.DestinationPortMin, rule.DestinationPortMax)
+	case "icmp":
+		return p.protocol == "icmp" && matchesICMP(rule.Type, rule.Code, p)
+	}
+	return false
+}
+
+// cidrContains returns true if a CIDR block, or an address taken as a host prefix, contains an
+// address.
+func cidrContains(cidr string, address netip.Addr) bool {
+	prefix, err := vpcbetav1.ParseAddressOrCIDR(cidr)
+	return err == nil && prefix.Contains(address)
+}
+
+// inRange returns true if a port is within a range (nil bounds are open).
+func inRange(port int64, min *int64, max *int64) bool {
+	return (min == nil || port >= *min) && (max == nil || port <= *max)
+}
+
+// matchesICMP returns true if the type and code of an ICMP packet match those of a rule (nil
+// matches any).
+func matchesICMP(ruleType *int64, ruleCode *int64, p *packet) bool {
+	matches := func(rule *int64, value *int64) bool {
+		return rule == nil || (value != nil && *rule == *value)
+	}
+	return matches(ruleType, p.icmpType) && matches(ruleCode, p.icmpCode)
+}
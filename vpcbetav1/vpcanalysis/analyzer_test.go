@@ -0,0 +1,216 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcanalysis_test
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcanalysis"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type object = map[string]interface{}
+type array = []interface{}
+
+// collection returns a list function returning the given pages of a collection property.
+func collection(property string, pages ...array) vpcbetav1.CollectionListFunc {
+	return func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+		page := 0
+		if start != nil {
+			page = int((*start)[0] - '0')
+		}
+		result := object{property: pages[page]}
+		if page+1 < len(pages) {
+			result["next"] = object{"href": "https://us-south.iaas.cloud.ibm.com/v1/" + property + "?start=" + string(rune('0'+page+1))}
+		}
+		return result, nil, nil
+	}
+}
+
+// aclRule returns a network ACL rule.
+func aclRule(name string, action string, direction string, protocol string, source string, destination string, extra ...interface{}) object {
+	rule := object{"id": name, "name": name, "action": action, "direction": direction, "protocol": protocol,
+		"source": source, "destination": destination}
+	for i := 0; i+1 < len(extra); i += 2 {
+		rule[extra[i].(string)] = extra[i+1]
+	}
+	return rule
+}
+
+var _ = Describe(`Model`, func() {
+	var model *vpcanalysis.Model
+
+	BeforeEach(func() {
+		var err error
+		model, err = vpcanalysis.Load(context.Background(), &vpcanalysis.LoadOptions{
+			Subnets: collection("subnets",
+				array{object{"id": "subnet-app", "name": "app", "ipv4_cidr_block": "10.0.1.0/24", "network_acl": object{"id": "acl-app"}}},
+				array{object{"id": "subnet-db", "name": "db", "ipv4_cidr_block": "10.0.2.0/24", "network_acl": object{"id": "acl-db"}}},
+			),
+			NetworkACLs: collection("network_acls", array{
+				object{"id": "acl-app", "name": "app-acl", "rules": array{
+					aclRule("allow-inbound", "allow", "inbound", "all", "0.0.0.0/0", "0.0.0.0/0"),
+					aclRule("allow-outbound", "allow", "outbound", "all", "0.0.0.0/0", "0.0.0.0/0"),
+				}},
+				object{"id": "acl-db", "name": "db-acl", "rules": array{
+					aclRule("deny-batch", "deny", "inbound", "tcp", "10.0.1.128/25", "10.0.2.0/24",
+						"destination_port_min", 5432, "destination_port_max", 5432),
+					aclRule("allow-postgres", "allow", "inbound", "tcp", "10.0.1.0/24", "10.0.2.0/24",
+						"destination_port_min", 5432, "destination_port_max", 5432),
+					aclRule("allow-replies", "allow", "outbound", "tcp", "10.0.2.0/24", "10.0.1.0/24",
+						"source_port_min", 5432, "source_port_max", 5432),
+					aclRule("allow-external", "allow", "inbound", "all", "0.0.0.0/0", "0.0.0.0/0"),
+				}},
+			}),
+			NetworkInterfaces: []vpcbetav1.CollectionListFunc{
+				collection("network_interfaces", array{
+					object{"id": "nic-app", "name": "app-nic", "subnet": object{"id": "subnet-app"},
+						"primary_ip": object{"address": "10.0.1.4"}, "security_groups": array{object{"id": "sg-app"}}},
+				}),
+				collection("network_interfaces", array{
+					object{"id": "nic-batch", "name": "batch-nic", "subnet": object{"id": "subnet-app"},
+						"primary_ip": object{"address": "10.0.1.200"}, "security_groups": array{object{"id": "sg-app"}}},
+				}),
+			},
+			VirtualNetworkInterfaces: collection("virtual_network_interfaces", array{
+				object{"id": "vni-db", "name": "db-vni", "subnet": object{"id": "subnet-db"}, "primary_ip": object{"address": "10.0.2.4"}},
+			}),
+			SecurityGroups: collection("security_groups", array{
+				object{"id": "sg-app", "name": "app-sg", "crn": "crn:sg-app", "rules": array{
+					object{"id": "app-out", "direction": "outbound", "protocol": "all"},
+				}},
+				object{"id": "sg-db", "name": "db-sg", "rules": array{
+					object{"id": "db-postgres", "direction": "inbound", "protocol": "tcp", "port_min": 5432, "port_max": 5432,
+						"remote": object{"crn": "crn:sg-app"}},
+				}, "targets": array{object{"id": "vni-db"}}},
+			}),
+			ReservedIPs: func(ctx context.Context, subnetID string, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+				if subnetID != "subnet-db" {
+					return object{"reserved_ips": array{}}, nil, nil
+				}
+				return object{"reserved_ips": array{
+					object{"id": "rip-1", "address": "10.0.2.10", "target": object{"id": "vni-db"}},
+					object{"id": "rip-2", "address": "10.0.2.11"},
+				}}, nil, nil
+			},
+		})
+		Expect(err).To(BeNil())
+	})
+
+	// trace returns the reasons of the steps of a result.
+	trace := func(result *vpcanalysis.Result) (steps []string) {
+		for _, step := range result.Trace {
+			steps = append(steps, step.String())
+		}
+		return
+	}
+
+	It(`Loads the resources of a VPC`, func() {
+		Expect(model.Subnets).To(HaveLen(2))
+		Expect(model.NetworkACLs["acl-db"].Rules).To(HaveLen(4))
+		Expect(model.Interfaces).To(HaveLen(3))
+		Expect(model.Interfaces["vni-db"].SecurityGroupIDs).To(Equal([]string{"sg-db"}))
+		Expect(model.Interfaces["vni-db"].Addresses).To(HaveLen(2))
+	})
+	It(`Traces allowed packets`, func() {
+		result, err := model.Analyze(&vpcanalysis.Query{Source: "app-nic", Destination: "db-vni", Protocol: "tcp", Port: 5432})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeTrue())
+		Expect(trace(result)).To(Equal([]string{
+			"request outbound security group: allowed (security group app-sg rule app-out outbound all to 0.0.0.0/0)",
+			"request outbound network acl: allowed (network ACL app-acl rule allow-outbound allow outbound all 0.0.0.0/0 -> 0.0.0.0/0)",
+			"request inbound network acl: allowed (network ACL db-acl rule allow-postgres allow inbound tcp 10.0.1.0/24 -> 10.0.2.0/24 port 5432-5432)",
+			"request inbound security group: allowed (security group db-sg rule db-postgres inbound tcp 5432-5432 from crn:sg-app)",
+			"response outbound network acl: allowed (network ACL db-acl rule allow-replies allow outbound tcp 10.0.2.0/24 -> 10.0.1.0/24 port 1-65535)",
+			"response inbound network acl: allowed (network ACL app-acl rule allow-inbound allow inbound all 0.0.0.0/0 -> 0.0.0.0/0)",
+		}))
+		Expect(result.Trace[3].ResourceID).To(Equal("sg-db"))
+		Expect(result.Trace[3].SecurityGroupRule.ID).To(Equal("db-postgres"))
+		Expect(result.Trace[2].NetworkACLRule.Name).To(Equal("allow-postgres"))
+
+		// Reserved IPs bound to an interface are addresses of the interface.
+		result, err = model.Analyze(&vpcanalysis.Query{Source: "nic-app", Destination: "10.0.2.10", Protocol: "TCP", Port: 5432})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeTrue())
+	})
+	It(`Traces denied packets`, func() {
+		result, err := model.Analyze(&vpcanalysis.Query{Source: "batch-nic", Destination: "db-vni", Protocol: "tcp", Port: 5432})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(result.Trace[2].Allowed).To(BeFalse())
+		Expect(result.Trace[2].NetworkACLRule.Name).To(Equal("deny-batch"))
+
+		result, err = model.Analyze(&vpcanalysis.Query{Source: "app-nic", Destination: "db-vni", Protocol: "tcp", Port: 22})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(trace(result)[3]).To(Equal("request inbound security group: denied (no inbound rule of security groups db-sg allows the packet)"))
+		Expect(trace(result)[4]).To(Equal("response outbound network acl: denied (no outbound rule of network ACL db-acl matches the packet)"))
+		Expect(result.String()).To(HavePrefix("unreachable\n  request outbound security group: allowed"))
+	})
+	It(`Skips network ACLs within a subnet and rules of external endpoints`, func() {
+		result, err := model.Analyze(&vpcanalysis.Query{Source: "app-nic", Destination: "batch-nic", Protocol: "icmp"})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(trace(result)).To(Equal([]string{
+			"request outbound security group: allowed (security group app-sg rule app-out outbound all to 0.0.0.0/0)",
+			"request inbound security group: denied (no inbound rule of security groups app-sg allows the packet)",
+		}))
+
+		result, err = model.Analyze(&vpcanalysis.Query{Source: "203.0.113.5", Destination: "10.0.2.11", Protocol: "udp", Port: 53})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(trace(result)).To(Equal([]string{
+			"request inbound network acl: allowed (network ACL db-acl rule allow-external allow inbound all 0.0.0.0/0 -> 0.0.0.0/0)",
+			"response outbound network acl: denied (no outbound rule of network ACL db-acl matches the packet)",
+		}))
+	})
+	It(`Matches network ACL rules with addresses`, func() {
+		Expect(model.AddNetworkACL(object{"id": "acl-db", "name": "db-acl", "rules": array{
+			aclRule("deny-batch-host", "deny", "inbound", "all", "10.0.1.200", "10.0.2.0/24"),
+			aclRule("allow-inbound", "allow", "inbound", "all", "0.0.0.0/0", "0.0.0.0/0"),
+			aclRule("allow-outbound", "allow", "outbound", "all", "0.0.0.0/0", "0.0.0.0/0"),
+		}})).To(Succeed())
+		result, err := model.Analyze(&vpcanalysis.Query{Source: "batch-nic", Destination: "db-vni", Protocol: "tcp", Port: 5432})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(result.Trace[2].NetworkACLRule.Name).To(Equal("deny-batch-host"))
+
+		// Rules built by hand may hold plain addresses too.
+		model.NetworkACLs["acl-db"].Rules[0].Source = "10.0.1.4"
+		result, err = model.Analyze(&vpcanalysis.Query{Source: "app-nic", Destination: "db-vni", Protocol: "tcp", Port: 5432})
+		Expect(err).To(BeNil())
+		Expect(result.Reachable).To(BeFalse())
+		Expect(result.Trace[2].NetworkACLRule.Name).To(Equal("deny-batch-host"))
+	})
+	It(`Invoke Load with error`, func() {
+		_, err := vpcanalysis.Load(context.Background(), nil)
+		Expect(err).ToNot(BeNil())
+	})
+	It(`Rejects invalid queries`, func() {
+		for _, query := range []*vpcanalysis.Query{
+			{Source: "missing-nic", Destination: "db-vni", Protocol: "tcp", Port: 22},
+			{Source: "app-nic", Destination: "db-vni", Protocol: "gre"},
+			{Source: "app-nic", Destination: "db-vni", Protocol: "tcp"},
+		} {
+			_, err := model.Analyze(query)
+			Expect(err).ToNot(BeNil())
+		}
+	})
+})
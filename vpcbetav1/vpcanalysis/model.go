@@ -0,0 +1,344 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcanalysis answers reachability questions about a VPC offline, such as "can
+// instance A reach B on TCP port 5432?". It loads the subnets, network ACLs, security groups,
+// network interfaces (or virtual network interfaces) and reserved IPs of a VPC into a Model,
+// then evaluates the network ACL and security group rules that apply to a packet and to its
+// response, and returns a trace of the rules that allowed or denied them.
+//
+// The analysis follows the semantics of the VPC: security groups are stateful and apply to
+// network interfaces, network ACLs are stateless, apply to the traffic entering and leaving a
+// subnet and are evaluated in order, and both deny the traffic that no rule allows.
+//
+// Example:
+//
+//	model, err := vpcanalysis.Load(ctx, &vpcanalysis.LoadOptions{...})
+//	result, err := model.Analyze(&vpcanalysis.Query{
+//		Source: "instance-a-nic", Destination: "10.240.1.5", Protocol: "tcp", Port: 5432,
+//	})
+//	fmt.Println(result)
+package vpcanalysis
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+)
+
+// Subnet : A subnet of a Model.
+type Subnet struct {
+	ID   string
+	Name string
+	CIDR netip.Prefix
+
+	// The ID of the network ACL attached to the subnet.
+	NetworkACLID string
+}
+
+// NetworkACL : A network ACL of a Model, with its rules in order.
+type NetworkACL struct {
+	ID    string
+	Name  string
+	Rules []*vpcbetav1.NormalizedNetworkACLRule
+}
+
+// SecurityGroup : A security group of a Model, with its rules.
+type SecurityGroup struct {
+	ID    string
+	CRN   string
+	Href  string
+	Name  string
+	Rules []*vpcbetav1.NormalizedSecurityGroupRule
+}
+
+// Interface : A network interface or virtual network interface of a Model.
+type Interface struct {
+	ID   string
+	Name string
+
+	// The ID of the subnet of the interface.
+	SubnetID string
+
+	// The addresses of the interface: its primary IP and its other reserved IPs.
+	Addresses []netip.Addr
+
+	// The IDs of the security groups of the interface.
+	SecurityGroupIDs []string
+}
+
+// Model : The network configuration of a VPC: its subnets, network ACLs, security groups and
+// interfaces. Add resources with the Add... methods, or load them with Load.
+type Model struct {
+	Subnets        map[string]*Subnet
+	NetworkACLs    map[string]*NetworkACL
+	SecurityGroups map[string]*SecurityGroup
+	Interfaces     map[string]*Interface
+}
+
+// NewModel returns a new, empty Model.
+func NewModel() *Model {
+	return &Model{
+		Subnets:        make(map[string]*Subnet),
+		NetworkACLs:    make(map[string]*NetworkACL),
+		SecurityGroups: make(map[string]*SecurityGroup),
+		Interfaces:     make(map[string]*Interface),
+	}
+}
+
+// stringOf returns the string property of a JSON object, or of one of its object properties.
+func stringOf(object map[string]interface{}, keys ...string) string {
+	for _, key := range keys[:len(keys)-1] {
+		object, _ = object[key].(map[string]interface{})
+	}
+	value, _ := object[keys[len(keys)-1]].(string)
+	return value
+}
+
+// AddSubnet adds a subnet (the Subnet model).
+func (model *Model) AddSubnet(subnet interface{}) error {
+	object, err := vpcbetav1.ToJSONObject(subnet)
+	if err != nil {
+		return err
+	}
+	cidr, err := netip.ParsePrefix(stringOf(object, "ipv4_cidr_block"))
+	if err != nil {
+		return fmt.Errorf("subnet %s: %w", stringOf(object, "id"), err)
+	}
+	model.Subnets[stringOf(object, "id")] = &Subnet{
+		ID:           stringOf(object, "id"),
+		Name:         stringOf(object, "name"),
+		CIDR:         cidr.Masked(),
+		NetworkACLID: stringOf(object, "network_acl", "id"),
+	}
+	return nil
+}
+
+// AddNetworkACL adds a network ACL (the NetworkACL model), with its rules in order.
+func (model *Model) AddNetworkACL(networkACL interface{}) error {
+	object, err := vpcbetav1.ToJSONObject(networkACL)
+	if err != nil {
+		return err
+	}
+	acl := &NetworkACL{ID: stringOf(object, "id"), Name: stringOf(object, "name")}
+	rules, _ := object["rules"].([]interface{})
+	for _, item := range rules {
+		rule, err := vpcbetav1.NormalizeNetworkACLRule(item)
+		if err != nil {
+			return fmt.Errorf("network ACL %s: %w", acl.ID, err)
+		}
+		acl.Rules = append(acl.Rules, rule)
+	}
+	model.NetworkACLs[acl.ID] = acl
+	return nil
+}
+
+// AddSecurityGroup adds a security group (the SecurityGroup model), with its rules. The
+// network interfaces and virtual network interfaces among its targets are added to it.
+func (model *Model) AddSecurityGroup(securityGroup interface{}) error {
+	object, err := vpcbetav1.ToJSONObject(securityGroup)
+	if err != nil {
+		return err
+	}
+	sg := &SecurityGroup{
+		ID:   stringOf(object, "id"),
+		CRN:  stringOf(object, "crn"),
+		Href: stringOf(object, "href"),
+		Name: stringOf(object, "name"),
+	}
+	rules, _ := object["rules"].([]interface{})
+	for _, item := range rules {
+		rule, err := vpcbetav1.NormalizeSecurityGroupRule(item)
+		if err != nil {
+			return fmt.Errorf("security group %s: %w", sg.ID, err)
+		}
+		sg.Rules = append(sg.Rules, rule)
+	}
+	model.SecurityGroups[sg.ID] = sg
+
+	targets, _ := object["targets"].([]interface{})
+	for _, target := range targets {
+		targetObject, _ := target.(map[string]interface{})
+		if iface := model.Interfaces[stringOf(targetObject, "id")]; iface != nil {
+			iface.addSecurityGroup(sg.ID)
+		}
+	}
+	return nil
+}
+
+// addSecurityGroup adds a security group to the interface, once.
+func (iface *Interface) addSecurityGroup(id string) {
+	for _, existing := range iface.SecurityGroupIDs {
+		if existing == id {
+			return
+		}
+	}
+	iface.SecurityGroupIDs = append(iface.SecurityGroupIDs, id)
+}
+
+// AddInterface adds a network interface (the NetworkInterface or BareMetalServerNetworkInterface
+// models) or a virtual network interface (the VirtualNetworkInterface model), with its subnet,
+// primary IP, reserved IPs and security groups.
+func (model *Model) AddInterface(networkInterface interface{}) error {
+	object, err := vpcbetav1.ToJSONObject(networkInterface)
+	if err != nil {
+		return err
+	}
+	iface := &Interface{
+		ID:       stringOf(object, "id"),
+		Name:     stringOf(object, "name"),
+		SubnetID: stringOf(object, "subnet", "id"),
+	}
+	if model.Interfaces[iface.ID] != nil {
+		iface = model.Interfaces[iface.ID]
+	}
+	addresses := []string{stringOf(object, "primary_ip", "address"), stringOf(object, "primary_ipv4_address")}
+	ips, _ := object["ips"].([]interface{})
+	for _, ip := range ips {
+		ipObject, _ := ip.(map[string]interface{})
+		addresses = append(addresses, stringOf(ipObject, "address"))
+	}
+	for _, address := range addresses {
+		if address == "" || address == "0.0.0.0" {
+			continue
+		}
+		if err = iface.addAddress(address); err != nil {
+			return fmt.Errorf("interface %s: %w", iface.ID, err)
+		}
+	}
+	securityGroups, _ := object["security_groups"].([]interface{})
+	for _, sg := range securityGroups {
+		sgObject, _ := sg.(map[string]interface{})
+		iface.addSecurityGroup(stringOf(sgObject, "id"))
+	}
+	model.Interfaces[iface.ID] = iface
+	return nil
+}
+
+// addAddress adds an address to the interface, once.
+func (iface *Interface) addAddress(value string) error {
+	address, err := netip.ParseAddr(value)
+	if err != nil {
+		return err
+	}
+	for _, existing := range iface.Addresses {
+		if existing == address {
+			return nil
+		}
+	}
+	iface.Addresses = append(iface.Addresses, address)
+	return nil
+}
+
+// AddReservedIP adds a reserved IP (the ReservedIP model) to the interface that is its target,
+// if any.
+func (model *Model) AddReservedIP(reservedIP interface{}) error {
+	object, err := vpcbetav1.ToJSONObject(reservedIP)
+	if err != nil {
+		return err
+	}
+	iface := model.Interfaces[stringOf(object, "target", "id")]
+	if iface == nil {
+		return nil
+	}
+	return iface.addAddress(stringOf(object, "address"))
+}
+
+// LoadOptions : The LoadOptions struct configures Load. The functions are usually thin
+// wrappers around the generated List...WithContext operations, filtered by VPC (see
+// vpcbetav1.CollectionListFunc). Functions that are not set are not called.
+type LoadOptions struct {
+	// Lists the subnets (the SubnetCollection model).
+	Subnets vpcbetav1.CollectionListFunc
+
+	// Lists the network ACLs, with their rules (the NetworkACLCollection model).
+	NetworkACLs vpcbetav1.CollectionListFunc
+
+	// Lists network interfaces (a collection with a `network_interfaces` property, such as the
+	// NetworkInterfaceUnpaginatedCollection model) and virtual network interfaces (the
+	// VirtualNetworkInterfaceCollection model).
+	NetworkInterfaces        []vpcbetav1.CollectionListFunc
+	VirtualNetworkInterfaces vpcbetav1.CollectionListFunc
+
+	// Lists the security groups, with their rules and targets (the SecurityGroupCollection
+	// model).
+	SecurityGroups vpcbetav1.CollectionListFunc
+
+	// Lists the reserved IPs of a subnet (the ReservedIPCollection model).
+	ReservedIPs func(ctx context.Context, subnetID string, start *string, limit *int64) (collection interface{}, response *core.DetailedResponse, err error)
+}
+
+// loadStep lists the items of a collection property and adds them to a Model.
+type loadStep struct {
+	list     vpcbetav1.CollectionListFunc
+	property string
+	add      func(item interface{}) error
+}
+
+// Load returns a new Model of the resources listed with the given functions.
+func Load(ctx context.Context, options *LoadOptions) (model *Model, err error) {
+	if options == nil {
+		err = fmt.Errorf("options cannot be nil")
+		return
+	}
+	model = NewModel()
+	// Interfaces are added before security groups, so that the targets of security groups are
+	// known.
+	steps := []*loadStep{
+		{options.Subnets, "subnets", model.AddSubnet},
+		{options.NetworkACLs, "network_acls", model.AddNetworkACL},
+		{options.VirtualNetworkInterfaces, "virtual_network_interfaces", model.AddInterface},
+	}
+	for _, list := range options.NetworkInterfaces {
+		steps = append(steps, &loadStep{list, "network_interfaces", model.AddInterface})
+	}
+	steps = append(steps, &loadStep{options.SecurityGroups, "security_groups", model.AddSecurityGroup})
+
+	for _, step := range steps {
+		if step.list == nil {
+			continue
+		}
+		if err = loadItems(ctx, step.list, step.property, step.add); err != nil {
+			return nil, err
+		}
+	}
+	if options.ReservedIPs != nil {
+		for subnetID := range model.Subnets {
+			subnetID := subnetID
+			list := func(ctx context.Context, start *string, limit *int64) (interface{}, *core.DetailedResponse, error) {
+				return options.ReservedIPs(ctx, subnetID, start, limit)
+			}
+			if err = loadItems(ctx, list, "reserved_ips", model.AddReservedIP); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+// loadItems adds the items of the given property of all the pages of a collection.
+func loadItems(ctx context.Context, list vpcbetav1.CollectionListFunc, property string, add func(item interface{}) error) (err error) {
+	vpcbetav1.CollectionObjects(ctx, list, property, nil)(func(item map[string]interface{}, itemErr error) bool {
+		if err = itemErr; err == nil {
+			err = add(item)
+		}
+		return err == nil
+	})
+	return
+}
@@ -0,0 +1,156 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcfake
+
+// kind describes one resource collection served by the fake server.
+type kind struct {
+	// The path segment of the collection (e.g. "vpcs" or "rules").
+	path string
+
+	// The path segment of the parent collection for sub-resources (e.g. "security_groups").
+	parent string
+
+	// The value of the `resource_type` property, or "" if the resource has none.
+	resourceType string
+
+	// The property holding the lifecycle status, its value after creation and the value it
+	// transitions to once the resource has been read Server.TransitionReads times.
+	statusField   string
+	initialStatus string
+	finalStatus   string
+
+	// The properties a create request must specify.
+	required []string
+
+	// The status code of a successful delete request.
+	deleteStatus int
+
+	// Whether the order of the collection is significant and driven by the `before` property.
+	ordered bool
+}
+
+// kinds lists the collections implemented by the fake server.
+var kinds = []*kind{
+	{
+		path:          "vpcs",
+		resourceType:  "vpc",
+		statusField:   "status",
+		initialStatus: "pending",
+		finalStatus:   "available",
+		deleteStatus:  204,
+	},
+	{
+		path:         "address_prefixes",
+		parent:       "vpcs",
+		required:     []string{"cidr", "zone"},
+		deleteStatus: 204,
+	},
+	{
+		path:          "subnets",
+		resourceType:  "subnet",
+		statusField:   "status",
+		initialStatus: "pending",
+		finalStatus:   "available",
+		required:      []string{"vpc"},
+		deleteStatus:  204,
+	},
+	{
+		path:         "security_groups",
+		resourceType: "security_group",
+		required:     []string{"vpc"},
+		deleteStatus: 204,
+	},
+	{
+		path:         "rules",
+		parent:       "security_groups",
+		required:     []string{"direction", "protocol"},
+		deleteStatus: 204,
+	},
+	{
+		path:         "network_acls",
+		resourceType: "network_acl",
+		required:     []string{"vpc"},
+		deleteStatus: 204,
+	},
+	{
+		path:         "rules",
+		parent:       "network_acls",
+		required:     []string{"action", "destination", "direction", "protocol", "source"},
+		deleteStatus: 204,
+		ordered:      true,
+	},
+	{
+		path:          "floating_ips",
+		resourceType:  "floating_ip",
+		statusField:   "status",
+		initialStatus: "pending",
+		finalStatus:   "available",
+		deleteStatus:  204,
+	},
+	{
+		path:         "keys",
+		resourceType: "key",
+		required:     []string{"public_key"},
+		deleteStatus: 204,
+	},
+	{
+		path:          "instances",
+		resourceType:  "instance",
+		statusField:   "status",
+		initialStatus: "pending",
+		finalStatus:   "running",
+		required:      []string{"profile", "zone"},
+		deleteStatus:  204,
+	},
+	{
+		path:          "volumes",
+		resourceType:  "volume",
+		statusField:   "status",
+		initialStatus: "pending",
+		finalStatus:   "available",
+		required:      []string{"profile", "zone"},
+		deleteStatus:  204,
+	},
+	{
+		path:          "snapshots",
+		resourceType:  "snapshot",
+		statusField:   "lifecycle_state",
+		initialStatus: "pending",
+		finalStatus:   "stable",
+		required:      []string{"source_volume"},
+		deleteStatus:  204,
+	},
+	{
+		path:          "shares",
+		resourceType:  "share",
+		statusField:   "lifecycle_state",
+		initialStatus: "pending",
+		finalStatus:   "stable",
+		required:      []string{"profile", "size", "zone"},
+		deleteStatus:  202,
+	},
+}
+
+// findKind returns the kind served at the given collection path, or nil.
+func findKind(parent string, path string) *kind {
+	for _, k := range kinds {
+		if k.parent == parent && k.path == path {
+			return k
+		}
+	}
+	return nil
+}
@@ -0,0 +1,862 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcfake provides an in-memory, stateful fake of a subset of the VPC beta API
+// for testing code that uses the vpcbetav1 package without an IBM Cloud account.
+//
+// The fake implements the list, create, get, update and delete operations of VPCs, VPC
+// address prefixes, subnets, security groups and their rules, network ACLs and their rules,
+// floating IPs, keys, instances, volumes, snapshots and shares, with ETags, `start`/`limit`
+// pagination, property filters, `status`/`lifecycle_state` transitions and the error
+// responses (400, 404, 409, 412) of the real API. Authentication is not checked.
+//
+// Example:
+//
+//	server := vpcfake.NewServer()
+//	defer server.Close()
+//
+//	vpcService, err := vpcbetav1.NewVpcbetaV1(&vpcbetav1.VpcbetaV1Options{
+//		URL:           server.ServiceURL(),
+//		Authenticator: &core.NoAuthAuthenticator{},
+//	})
+package vpcfake
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// The default and maximum page sizes of list operations.
+	defaultLimit = 50
+	maxLimit     = 100
+
+	// The CRN prefix of the resources created by the fake server.
+	crnPrefix = "crn:v1:bluemix:public:is:us-south:a/aa2432b1fa4d4ace891e9b80fc104e34::"
+)
+
+var versionPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// Server is an in-memory fake of the VPC beta API, served by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	// The number of times a newly created resource must be read before it transitions from
+	// its initial status (e.g. `pending`) to its final status (e.g. `available`).
+	// A value of 0 makes resources reach their final status immediately.
+	TransitionReads int
+
+	mutex   sync.Mutex
+	stores  map[string]*store
+	counter int
+}
+
+// store holds the resources of one collection in creation (or `before`) order.
+type store struct {
+	order []string
+	items map[string]*resource
+}
+
+// resource is a stored resource along with its bookkeeping.
+type resource struct {
+	data      map[string]interface{}
+	etag      string
+	reads     int
+	allocated int
+}
+
+// apiError is an error response to send back to the client.
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+// NewServer starts and returns a new fake server. The caller should call Close when finished.
+func NewServer() *Server {
+	server := &Server{
+		TransitionReads: 1,
+		stores:          make(map[string]*store),
+	}
+	server.Server = httptest.NewServer(server)
+	return server
+}
+
+// ServiceURL returns the service URL to configure on the vpcbetav1 service.
+func (server *Server) ServiceURL() string {
+	return server.URL + "/v1"
+}
+
+// ServeHTTP dispatches a request to the matching collection or resource handler.
+func (server *Server) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	requestID := req.Header.Get("X-Request-Id")
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	res.Header().Set("X-Request-Id", requestID)
+
+	if !versionPattern.MatchString(req.URL.Query().Get("version")) {
+		writeError(res, &apiError{http.StatusBadRequest, "validation_invalid_argument",
+			"The 'version' query parameter is required and must be a date in the format YYYY-MM-DD."})
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/v1"), "/"), "/")
+	var k *kind
+	var parentID, id string
+	switch len(segments) {
+	case 1, 2:
+		k = findKind("", segments[0])
+	case 3, 4:
+		if parent := findKind("", segments[0]); parent != nil {
+			if server.lookup(parent, "", segments[1]) == nil {
+				writeError(res, notFound(parent, segments[1]))
+				return
+			}
+			k = findKind(segments[0], segments[2])
+			parentID = segments[1]
+		}
+	}
+	if k == nil {
+		writeError(res, &apiError{http.StatusNotFound, "not_found", "The requested path does not exist."})
+		return
+	}
+	if len(segments) == 2 || len(segments) == 4 {
+		id = segments[len(segments)-1]
+	}
+
+	if id == "" {
+		switch req.Method {
+		case http.MethodGet:
+			server.list(res, req, k, parentID)
+		case http.MethodPost:
+			server.create(res, req, k, parentID)
+		default:
+			writeError(res, &apiError{http.StatusMethodNotAllowed, "method_not_allowed", "The method is not allowed."})
+		}
+		return
+	}
+
+	r := server.lookup(k, parentID, id)
+	if r == nil {
+		writeError(res, notFound(k, id))
+		return
+	}
+	switch req.Method {
+	case http.MethodGet:
+		server.advance(k, r)
+		writeResource(res, http.StatusOK, r, server.render(k, parentID, r))
+	case http.MethodPatch:
+		server.update(res, req, k, parentID, r)
+	case http.MethodDelete:
+		server.delete(res, req, k, parentID, r)
+	default:
+		writeError(res, &apiError{http.StatusMethodNotAllowed, "method_not_allowed", "The method is not allowed."})
+	}
+}
+
+// list handles GET requests on a collection.
+func (server *Server) list(res http.ResponseWriter, req *http.Request, k *kind, parentID string) {
+	query := req.URL.Query()
+	limit := defaultLimit
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxLimit {
+			writeError(res, &apiError{http.StatusBadRequest, "validation_invalid_argument",
+				fmt.Sprintf("The 'limit' query parameter must be between 1 and %d.", maxLimit)})
+			return
+		}
+	}
+
+	var matches []*resource
+	for _, r := range server.all(k, parentID) {
+		if matchesFilters(r.data, query) {
+			matches = append(matches, r)
+		}
+	}
+
+	begin := 0
+	if start := query.Get("start"); start != "" {
+		begin = -1
+		for i, r := range matches {
+			if r.data["id"] == start {
+				begin = i
+				break
+			}
+		}
+		if begin < 0 {
+			writeError(res, &apiError{http.StatusBadRequest, "validation_invalid_argument",
+				"The 'start' query parameter is not valid."})
+			return
+		}
+	}
+	end := begin + limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	collectionHref := server.ServiceURL() + collectionPath(k, parentID)
+	items := []interface{}{}
+	for _, r := range matches[begin:end] {
+		server.advance(k, r)
+		items = append(items, server.render(k, parentID, r))
+	}
+	body := map[string]interface{}{
+		"first":       map[string]interface{}{"href": fmt.Sprintf("%s?limit=%d", collectionHref, limit)},
+		"limit":       limit,
+		"total_count": len(matches),
+		k.path:        items,
+	}
+	if end < len(matches) {
+		body["next"] = map[string]interface{}{
+			"href": fmt.Sprintf("%s?limit=%d&start=%s", collectionHref, limit, matches[end].data["id"]),
+		}
+	}
+	writeJSON(res, http.StatusOK, body)
+}
+
+// create handles POST requests on a collection.
+func (server *Server) create(res http.ResponseWriter, req *http.Request, k *kind, parentID string) {
+	body, apiErr := readBody(req)
+	if apiErr == nil {
+		for _, field := range k.required {
+			if _, ok := body[field]; !ok {
+				apiErr = &apiError{http.StatusBadRequest, "missing_field",
+					fmt.Sprintf("The '%s' property is required.", field)}
+				break
+			}
+		}
+	}
+	if apiErr == nil {
+		var r *resource
+		r, apiErr = server.insert(k, parentID, body)
+		if apiErr == nil {
+			writeResource(res, http.StatusCreated, r, server.render(k, parentID, r))
+			return
+		}
+	}
+	writeError(res, apiErr)
+}
+
+// update handles PATCH requests on a resource, applying the body as a JSON merge patch.
+func (server *Server) update(res http.ResponseWriter, req *http.Request, k *kind, parentID string, r *resource) {
+	if apiErr := checkIfMatch(req, r); apiErr != nil {
+		writeError(res, apiErr)
+		return
+	}
+	patch, apiErr := readBody(req)
+	if apiErr != nil {
+		writeError(res, apiErr)
+		return
+	}
+
+	if before, ok := patch["before"]; ok && k.ordered {
+		delete(patch, "before")
+		if apiErr = server.move(k, parentID, r.data["id"].(string), refID(before)); apiErr != nil {
+			writeError(res, apiErr)
+			return
+		}
+	}
+	for _, field := range []string{"id", "href", "crn", "created_at", "resource_type"} {
+		delete(patch, field)
+	}
+	mergePatch(r.data, patch)
+	r.etag = newETag()
+
+	writeResource(res, http.StatusOK, r, server.render(k, parentID, r))
+}
+
+// delete handles DELETE requests on a resource.
+func (server *Server) delete(res http.ResponseWriter, req *http.Request, k *kind, parentID string, r *resource) {
+	if apiErr := checkIfMatch(req, r); apiErr != nil {
+		writeError(res, apiErr)
+		return
+	}
+	id := r.data["id"].(string)
+	if apiErr := server.checkInUse(k, id); apiErr != nil {
+		writeError(res, apiErr)
+		return
+	}
+
+	server.remove(k, parentID, id)
+	if k.deleteStatus == http.StatusAccepted {
+		if k.statusField != "" {
+			r.data[k.statusField] = "deleting"
+		}
+		writeJSON(res, http.StatusAccepted, server.render(k, parentID, r))
+		return
+	}
+	res.WriteHeader(k.deleteStatus)
+}
+
+// insert creates a resource from a create request body, applying the defaults and the
+// side effects of the real API.
+func (server *Server) insert(k *kind, parentID string, body map[string]interface{}) (r *resource, apiErr *apiError) {
+	server.counter++
+	id := fmt.Sprintf("r006-%s", uuid.New().String())
+	data := body
+	data["id"] = id
+	data["href"] = server.ServiceURL() + collectionPath(k, parentID) + "/" + id
+	data["created_at"] = time.Now().UTC().Format(time.RFC3339)
+	if k.resourceType != "" {
+		data["resource_type"] = k.resourceType
+		data["crn"] = crnPrefix + k.resourceType + ":" + id
+	}
+	if _, ok := data["name"]; !ok {
+		data["name"] = fmt.Sprintf("%s-%d", strings.TrimSuffix(k.path, "s"), server.counter)
+	}
+	if k.statusField != "" {
+		data[k.statusField] = k.initialStatus
+		if server.TransitionReads <= 0 {
+			data[k.statusField] = k.finalStatus
+		}
+	}
+
+	var children map[string][]interface{}
+	switch k.path {
+	case "subnets":
+		apiErr = server.prepareSubnet(data)
+	case "security_groups", "network_acls":
+		if server.lookup(findKind("", "vpcs"), "", refID(data["vpc"])) == nil {
+			return nil, notFound(findKind("", "vpcs"), refID(data["vpc"]))
+		}
+		if rules, ok := data["rules"].([]interface{}); ok {
+			children = map[string][]interface{}{"rules": rules}
+		}
+		delete(data, "rules")
+	case "instances":
+		apiErr = server.prepareInstance(data)
+	case "snapshots":
+		if server.lookup(findKind("", "volumes"), "", refID(data["source_volume"])) == nil {
+			apiErr = notFound(findKind("", "volumes"), refID(data["source_volume"]))
+		}
+	case "floating_ips":
+		data["address"] = fmt.Sprintf("203.0.113.%d", server.counter%254+1)
+	case "keys":
+		if _, ok := data["type"]; !ok {
+			data["type"] = "rsa"
+		}
+		data["fingerprint"] = "SHA256:" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	case "volumes":
+		if _, ok := data["capacity"]; !ok {
+			data["capacity"] = 100
+		}
+	case "rules":
+		if _, ok := data["ip_version"]; !ok {
+			data["ip_version"] = "ipv4"
+		}
+	}
+	if apiErr != nil {
+		return
+	}
+
+	before := ""
+	if k.ordered {
+		before = refID(data["before"])
+		delete(data, "before")
+		if before != "" && server.lookup(k, parentID, before) == nil {
+			return nil, notFound(k, before)
+		}
+	}
+
+	r = &resource{data: data, etag: newETag()}
+	s := server.store(k, parentID)
+	s.items[id] = r
+	s.order = append(s.order, id)
+	if before != "" {
+		_ = server.move(k, parentID, id, before)
+	}
+
+	for path, items := range children {
+		child := findKind(k.path, path)
+		for _, item := range items {
+			if itemMap, ok := item.(map[string]interface{}); ok {
+				if _, apiErr = server.insert(child, id, itemMap); apiErr != nil {
+					return
+				}
+			}
+		}
+	}
+	if k.path == "vpcs" {
+		server.createDefaults(r)
+	}
+	return
+}
+
+// createDefaults creates the default network ACL and security group of a new VPC.
+func (server *Server) createDefaults(vpc *resource) {
+	vpcRef := server.reference(findKind("", "vpcs"), vpc)
+	vpcName := vpc.data["name"].(string)
+
+	acl, _ := server.insert(findKind("", "network_acls"), "", map[string]interface{}{
+		"name": vpcName + "-default-network-acl",
+		"vpc":  vpcRef,
+		"rules": []interface{}{
+			map[string]interface{}{"name": "allow-inbound", "action": "allow", "direction": "inbound",
+				"protocol": "all", "source": "0.0.0.0/0", "destination": "0.0.0.0/0"},
+			map[string]interface{}{"name": "allow-outbound", "action": "allow", "direction": "outbound",
+				"protocol": "all", "source": "0.0.0.0/0", "destination": "0.0.0.0/0"},
+		},
+	})
+	vpc.data["default_network_acl"] = server.reference(findKind("", "network_acls"), acl)
+
+	sg, _ := server.insert(findKind("", "security_groups"), "", map[string]interface{}{
+		"name": vpcName + "-default-security-group",
+		"vpc":  vpcRef,
+	})
+	sgRef := server.reference(findKind("", "security_groups"), sg)
+	rules := findKind("security_groups", "rules")
+	_, _ = server.insert(rules, sg.data["id"].(string), map[string]interface{}{
+		"direction": "inbound", "protocol": "all", "remote": sgRef,
+	})
+	_, _ = server.insert(rules, sg.data["id"].(string), map[string]interface{}{
+		"direction": "outbound", "protocol": "all", "remote": map[string]interface{}{"cidr_block": "0.0.0.0/0"},
+	})
+	vpc.data["default_security_group"] = sgRef
+}
+
+// prepareSubnet validates a new subnet and assigns its CIDR block and network ACL.
+func (server *Server) prepareSubnet(data map[string]interface{}) *apiError {
+	vpc := server.lookup(findKind("", "vpcs"), "", refID(data["vpc"]))
+	if vpc == nil {
+		return notFound(findKind("", "vpcs"), refID(data["vpc"]))
+	}
+
+	var prefix netip.Prefix
+	if cidr, ok := data["ipv4_cidr_block"].(string); ok {
+		var err error
+		if prefix, err = netip.ParsePrefix(cidr); err != nil || !prefix.Addr().Is4() {
+			return &apiError{http.StatusBadRequest, "validation_invalid_argument",
+				"The 'ipv4_cidr_block' property must be a valid IPv4 CIDR block."}
+		}
+	} else if count, ok := data["total_ipv4_address_count"].(float64); ok && count >= 8 {
+		bits := 32
+		for size := 1; size < int(count); size <<= 1 {
+			bits--
+		}
+		prefix = netip.PrefixFrom(netip.AddrFrom4([4]byte{10, byte(server.counter % 256), 0, 0}), bits)
+	} else {
+		return &apiError{http.StatusBadRequest, "missing_field",
+			"Either the 'ipv4_cidr_block' or the 'total_ipv4_address_count' property is required."}
+	}
+	prefix = prefix.Masked()
+	total := 1 << (32 - prefix.Bits())
+	data["ipv4_cidr_block"] = prefix.String()
+	data["total_ipv4_address_count"] = total
+	data["available_ipv4_address_count"] = total - 5
+	if _, ok := data["network_acl"]; !ok {
+		data["network_acl"] = vpc.data["default_network_acl"]
+	}
+	data["vpc"] = server.reference(findKind("", "vpcs"), vpc)
+	return nil
+}
+
+// prepareInstance validates a new instance and sets up its primary network interface.
+func (server *Server) prepareInstance(data map[string]interface{}) *apiError {
+	nic, _ := data["primary_network_interface"].(map[string]interface{})
+	if nic == nil {
+		return nil
+	}
+	subnetKind := findKind("", "subnets")
+	subnet := server.lookup(subnetKind, "", refID(nic["subnet"]))
+	if subnet == nil {
+		return notFound(subnetKind, refID(nic["subnet"]))
+	}
+	vpc := server.lookup(findKind("", "vpcs"), "", refID(subnet.data["vpc"]))
+
+	prefix := netip.MustParsePrefix(subnet.data["ipv4_cidr_block"].(string))
+	base := binary.BigEndian.Uint32(prefix.Addr().AsSlice())
+	var address [4]byte
+	binary.BigEndian.PutUint32(address[:], base+4+uint32(subnet.allocated))
+	subnet.allocated++
+	subnet.data["available_ipv4_address_count"] = subnet.data["total_ipv4_address_count"].(int) - 5 - subnet.allocated
+
+	nic["id"] = uuid.New().String()
+	nic["resource_type"] = "network_interface"
+	nic["subnet"] = server.reference(subnetKind, subnet)
+	nic["primary_ip"] = map[string]interface{}{
+		"address":       netip.AddrFrom4(address).String(),
+		"id":            uuid.New().String(),
+		"resource_type": "subnet_reserved_ip",
+	}
+	if _, ok := nic["security_groups"]; !ok && vpc != nil {
+		nic["security_groups"] = []interface{}{vpc.data["default_security_group"]}
+	}
+	if vpc != nil {
+		data["vpc"] = server.reference(findKind("", "vpcs"), vpc)
+	}
+	return nil
+}
+
+// checkInUse returns a 409 Conflict error if the resource is still referenced by another one.
+func (server *Server) checkInUse(k *kind, id string) *apiError {
+	var dependents []string
+	switch k.path {
+	case "vpcs":
+		for _, sg := range server.all(findKind("", "security_groups"), "") {
+			if refID(sg.data["vpc"]) == id && !server.isDefault(sg) {
+				dependents = append(dependents, "security group")
+				break
+			}
+		}
+		for _, acl := range server.all(findKind("", "network_acls"), "") {
+			if refID(acl.data["vpc"]) == id && !server.isDefault(acl) {
+				dependents = append(dependents, "network ACL")
+				break
+			}
+		}
+		for _, path := range []string{"subnets", "instances"} {
+			for _, r := range server.all(findKind("", path), "") {
+				if refID(r.data["vpc"]) == id {
+					dependents = append(dependents, strings.TrimSuffix(path, "s"))
+					break
+				}
+			}
+		}
+	case "subnets":
+		for _, instance := range server.all(findKind("", "instances"), "") {
+			nic, _ := instance.data["primary_network_interface"].(map[string]interface{})
+			if nic != nil && refID(nic["subnet"]) == id {
+				dependents = append(dependents, "instance")
+				break
+			}
+		}
+	case "security_groups", "network_acls":
+		if server.isDefault(server.lookup(k, "", id)) {
+			dependents = append(dependents, "vpc")
+		}
+		for _, r := range append(server.all(findKind("", "subnets"), ""), server.all(findKind("", "instances"), "")...) {
+			if k.path == "network_acls" && refID(r.data["network_acl"]) == id {
+				dependents = append(dependents, "subnet")
+				break
+			}
+			if nic, _ := r.data["primary_network_interface"].(map[string]interface{}); nic != nil && k.path == "security_groups" {
+				if groups, _ := nic["security_groups"].([]interface{}); containsRef(groups, id) {
+					dependents = append(dependents, "instance")
+					break
+				}
+			}
+		}
+	case "volumes":
+		for _, instance := range server.all(findKind("", "instances"), "") {
+			attachments, _ := instance.data["volume_attachments"].([]interface{})
+			if boot, ok := instance.data["boot_volume_attachment"]; ok {
+				attachments = append(attachments, boot)
+			}
+			for _, attachment := range attachments {
+				if attachmentMap, ok := attachment.(map[string]interface{}); ok && refID(attachmentMap["volume"]) == id {
+					dependents = append(dependents, "instance")
+					break
+				}
+			}
+		}
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+	resourceType := k.resourceType
+	return &apiError{http.StatusConflict, resourceType + "_in_use",
+		fmt.Sprintf("The %s '%s' cannot be deleted while it is in use by a %s.", resourceType, id, dependents[0])}
+}
+
+// remove deletes a resource along with its sub-resources and, for VPCs, its defaults.
+func (server *Server) remove(k *kind, parentID string, id string) {
+	s := server.store(k, parentID)
+	r := s.items[id]
+	delete(s.items, id)
+	for i, entry := range s.order {
+		if entry == id {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+	for _, child := range kinds {
+		if child.parent == k.path && k.parent == "" {
+			delete(server.stores, storeKey(child, id))
+		}
+	}
+	if k.path == "vpcs" {
+		for _, path := range []string{"security_groups", "network_acls"} {
+			defaultID := refID(r.data["default_"+strings.TrimSuffix(path, "s")])
+			if server.lookup(findKind("", path), "", defaultID) != nil {
+				server.remove(findKind("", path), "", defaultID)
+			}
+		}
+	}
+}
+
+// move repositions a resource of an ordered collection before another one
+// (or at the end of the collection if before is "").
+func (server *Server) move(k *kind, parentID string, id string, before string) *apiError {
+	s := server.store(k, parentID)
+	if before != "" && s.items[before] == nil {
+		return notFound(k, before)
+	}
+	order := make([]string, 0, len(s.order))
+	for _, entry := range s.order {
+		if entry == before {
+			order = append(order, id)
+		}
+		if entry != id {
+			order = append(order, entry)
+		}
+	}
+	if before == "" {
+		order = append(order, id)
+	}
+	s.order = order
+	return nil
+}
+
+// advance moves a resource from its initial to its final status once it has been read enough.
+func (server *Server) advance(k *kind, r *resource) {
+	if k.statusField == "" || r.data[k.statusField] != k.initialStatus {
+		return
+	}
+	r.reads++
+	if r.reads >= server.TransitionReads {
+		r.data[k.statusField] = k.finalStatus
+		r.etag = newETag()
+	}
+}
+
+// render returns the representation of a resource, including computed properties.
+func (server *Server) render(k *kind, parentID string, r *resource) map[string]interface{} {
+	body := make(map[string]interface{}, len(r.data)+1)
+	for key, value := range r.data {
+		body[key] = value
+	}
+	if k.path == "security_groups" || k.path == "network_acls" {
+		rules := findKind(k.path, "rules")
+		items := []interface{}{}
+		for _, rule := range server.all(rules, r.data["id"].(string)) {
+			items = append(items, server.render(rules, r.data["id"].(string), rule))
+		}
+		body["rules"] = items
+	}
+	if k.ordered {
+		s := server.store(k, parentID)
+		for i, entry := range s.order {
+			if entry == r.data["id"] && i+1 < len(s.order) {
+				body["before"] = server.reference(k, s.items[s.order[i+1]])
+			}
+		}
+	}
+	return body
+}
+
+// reference returns the reference representation (id, href, name and crn) of a resource.
+func (server *Server) reference(k *kind, r *resource) map[string]interface{} {
+	ref := map[string]interface{}{}
+	for _, field := range []string{"id", "href", "name", "crn", "resource_type"} {
+		if value, ok := r.data[field]; ok {
+			ref[field] = value
+		}
+	}
+	return ref
+}
+
+// isDefault returns true if the security group or network ACL is the default of its VPC.
+func (server *Server) isDefault(r *resource) bool {
+	vpc := server.lookup(findKind("", "vpcs"), "", refID(r.data["vpc"]))
+	if vpc == nil {
+		return false
+	}
+	id := r.data["id"]
+	return refID(vpc.data["default_security_group"]) == id || refID(vpc.data["default_network_acl"]) == id
+}
+
+// lookup returns the resource with the given ID, or nil.
+func (server *Server) lookup(k *kind, parentID string, id string) *resource {
+	if k == nil {
+		return nil
+	}
+	return server.store(k, parentID).items[id]
+}
+
+// all returns the resources of a collection in order.
+func (server *Server) all(k *kind, parentID string) []*resource {
+	s := server.store(k, parentID)
+	resources := make([]*resource, 0, len(s.order))
+	for _, id := range s.order {
+		resources = append(resources, s.items[id])
+	}
+	return resources
+}
+
+// store returns the store of a collection, creating it if needed.
+func (server *Server) store(k *kind, parentID string) *store {
+	key := storeKey(k, parentID)
+	s := server.stores[key]
+	if s == nil {
+		s = &store{items: make(map[string]*resource)}
+		server.stores[key] = s
+	}
+	return s
+}
+
+// storeKey returns the key of the store of a collection.
+func storeKey(k *kind, parentID string) string {
+	if k.parent == "" {
+		return k.path
+	}
+	return k.parent + "/" + parentID + "/" + k.path
+}
+
+// collectionPath returns the path of a collection, relative to the service URL.
+func collectionPath(k *kind, parentID string) string {
+	if k.parent == "" {
+		return "/" + k.path
+	}
+	return "/" + k.parent + "/" + parentID + "/" + k.path
+}
+
+// matchesFilters returns true if the resource matches every property filter of the query
+// (e.g. `vpc.id=...` or `name=...`).
+func matchesFilters(data map[string]interface{}, query map[string][]string) bool {
+	for param, values := range query {
+		switch param {
+		case "version", "generation", "start", "limit":
+			continue
+		}
+		var value interface{} = data
+		for _, field := range strings.Split(param, ".") {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return false
+			}
+			value = object[field]
+		}
+		if value == nil || fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkIfMatch returns a 412 Precondition Failed error if the request's If-Match header
+// does not match the resource's ETag.
+func checkIfMatch(req *http.Request, r *resource) *apiError {
+	ifMatch := req.Header.Get("If-Match")
+	if ifMatch == "" || ifMatch == "*" || ifMatch == r.etag {
+		return nil
+	}
+	return &apiError{http.StatusPreconditionFailed, "precondition_failed",
+		"The provided If-Match value does not match the current ETag of the resource."}
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to target.
+func mergePatch(target map[string]interface{}, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		patchObject, isObject := value.(map[string]interface{})
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		if isObject && targetIsObject {
+			mergePatch(targetObject, patchObject)
+		} else {
+			target[key] = value
+		}
+	}
+}
+
+// refID returns the `id` property of a reference, or "".
+func refID(ref interface{}) string {
+	if object, ok := ref.(map[string]interface{}); ok {
+		if id, ok := object["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// containsRef returns true if one of the references has the given ID.
+func containsRef(refs []interface{}, id string) bool {
+	for _, ref := range refs {
+		if refID(ref) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// notFound returns a 404 Not Found error for a resource.
+func notFound(k *kind, id string) *apiError {
+	resourceType := k.resourceType
+	if resourceType == "" {
+		resourceType = strings.TrimSuffix(k.path, "s")
+	}
+	return &apiError{http.StatusNotFound, resourceType + "_not_found",
+		fmt.Sprintf("The %s '%s' could not be found.", strings.ReplaceAll(resourceType, "_", " "), id)}
+}
+
+// newETag returns a new weak ETag.
+func newETag() string {
+	return fmt.Sprintf(`W/"%s"`, uuid.New().String())
+}
+
+// readBody decodes the JSON object in the request body.
+func readBody(req *http.Request) (body map[string]interface{}, apiErr *apiError) {
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil || body == nil {
+		apiErr = &apiError{http.StatusBadRequest, "invalid_json", "The request body must be a JSON object."}
+	}
+	return
+}
+
+// writeResource writes a resource representation along with its ETag.
+func writeResource(res http.ResponseWriter, status int, r *resource, body map[string]interface{}) {
+	res.Header().Set("ETag", r.etag)
+	writeJSON(res, status, body)
+}
+
+// writeError writes an error response in the format of the VPC API.
+func writeError(res http.ResponseWriter, apiErr *apiError) {
+	writeJSON(res, apiErr.status, map[string]interface{}{
+		"errors": []interface{}{
+			map[string]interface{}{
+				"code":      apiErr.code,
+				"message":   apiErr.message,
+				"more_info": "https://cloud.ibm.com/docs/vpc?topic=vpc-rias-error-messages#" + apiErr.code,
+			},
+		},
+		"status_code": apiErr.status,
+		"trace":       uuid.New().String(),
+	})
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(res http.ResponseWriter, status int, body interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(body)
+}
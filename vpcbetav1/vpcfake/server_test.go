@@ -0,0 +1,270 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcfake_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe(`Server`, func() {
+	var server *vpcfake.Server
+
+	// call sends a request to the fake server and decodes the JSON response body.
+	call := func(method string, path string, body interface{}, headers ...string) (*http.Response, map[string]interface{}) {
+		var reader *bytes.Reader
+		if body != nil {
+			buf, err := json.Marshal(body)
+			Expect(err).To(BeNil())
+			reader = bytes.NewReader(buf)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		separator := "?"
+		if bytes.ContainsRune([]byte(path), '?') {
+			separator = "&"
+		}
+		req, err := http.NewRequest(method, server.ServiceURL()+path+separator+"version=2024-03-12", reader)
+		Expect(err).To(BeNil())
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		defer res.Body.Close()
+		var result map[string]interface{}
+		_ = json.NewDecoder(res.Body).Decode(&result)
+		return res, result
+	}
+
+	createVPC := func(name string) map[string]interface{} {
+		res, vpc := call(http.MethodPost, "/vpcs", map[string]interface{}{"name": name})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		return vpc
+	}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Creates, retrieves, updates and deletes a VPC`, func() {
+		vpc := createVPC("my-vpc")
+		Expect(vpc["id"]).ToNot(BeEmpty())
+		Expect(vpc["resource_type"]).To(Equal("vpc"))
+		Expect(vpc["status"]).To(Equal("pending"))
+		Expect(vpc["default_security_group"]).ToNot(BeNil())
+		Expect(vpc["default_network_acl"]).ToNot(BeNil())
+
+		path := fmt.Sprintf("/vpcs/%s", vpc["id"])
+		res, vpc := call(http.MethodGet, path, nil)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(vpc["status"]).To(Equal("available"))
+		Expect(res.Header.Get("ETag")).ToNot(BeEmpty())
+
+		res, vpc = call(http.MethodPatch, path, map[string]interface{}{"name": "my-vpc-updated"})
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(vpc["name"]).To(Equal("my-vpc-updated"))
+
+		res, _ = call(http.MethodDelete, path, nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+		res, body := call(http.MethodGet, path, nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(body["errors"].([]interface{})[0].(map[string]interface{})["code"]).To(Equal("vpc_not_found"))
+
+		res, body = call(http.MethodGet, "/security_groups", nil)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(body["security_groups"]).To(BeEmpty())
+	})
+	It(`Paginates and filters collections`, func() {
+		for i := 0; i < 5; i++ {
+			createVPC(fmt.Sprintf("vpc-%d", i))
+		}
+		var names []interface{}
+		path := "/vpcs?limit=2"
+		for pages := 0; ; pages++ {
+			Expect(pages).To(BeNumerically("<", 3))
+			res, body := call(http.MethodGet, path, nil)
+			Expect(res.StatusCode).To(Equal(http.StatusOK))
+			Expect(body["total_count"]).To(BeNumerically("==", 5))
+			for _, vpc := range body["vpcs"].([]interface{}) {
+				names = append(names, vpc.(map[string]interface{})["name"])
+			}
+			next, ok := body["next"].(map[string]interface{})
+			if !ok {
+				break
+			}
+			path = next["href"].(string)[len(server.ServiceURL()):]
+		}
+		Expect(names).To(Equal([]interface{}{"vpc-0", "vpc-1", "vpc-2", "vpc-3", "vpc-4"}))
+
+		res, body := call(http.MethodGet, "/vpcs?name=vpc-3", nil)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(body["vpcs"]).To(HaveLen(1))
+
+		res, _ = call(http.MethodGet, "/vpcs?limit=101", nil)
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+	It(`Enforces If-Match`, func() {
+		res, volume := call(http.MethodPost, "/volumes", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "general-purpose"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		etag := res.Header.Get("ETag")
+		path := fmt.Sprintf("/volumes/%s", volume["id"])
+
+		res, _ = call(http.MethodPatch, path, map[string]interface{}{"capacity": 200}, "If-Match", `W/"stale"`)
+		Expect(res.StatusCode).To(Equal(http.StatusPreconditionFailed))
+		res, volume = call(http.MethodPatch, path, map[string]interface{}{"capacity": 200}, "If-Match", etag)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(volume["capacity"]).To(BeNumerically("==", 200))
+		Expect(res.Header.Get("ETag")).ToNot(Equal(etag))
+	})
+	It(`Manages subnets, instances and their dependencies`, func() {
+		vpc := createVPC("my-vpc")
+		vpcRef := map[string]interface{}{"id": vpc["id"]}
+
+		res, prefix := call(http.MethodPost, fmt.Sprintf("/vpcs/%s/address_prefixes", vpc["id"]), map[string]interface{}{
+			"cidr": "10.0.0.0/16", "zone": map[string]interface{}{"name": "us-south-1"},
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		Expect(prefix["cidr"]).To(Equal("10.0.0.0/16"))
+
+		res, subnet := call(http.MethodPost, "/subnets", map[string]interface{}{
+			"vpc": vpcRef, "ipv4_cidr_block": "10.0.1.0/24",
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		Expect(subnet["available_ipv4_address_count"]).To(BeNumerically("==", 251))
+		Expect(subnet["network_acl"].(map[string]interface{})["id"]).To(Equal(vpc["default_network_acl"].(map[string]interface{})["id"]))
+
+		res, _ = call(http.MethodPost, "/subnets", map[string]interface{}{"vpc": vpcRef})
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+		res, _ = call(http.MethodPost, "/subnets", map[string]interface{}{"ipv4_cidr_block": "10.0.2.0/24"})
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res, instance := call(http.MethodPost, "/instances", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "bx2-2x8"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+			"primary_network_interface": map[string]interface{}{
+				"subnet": map[string]interface{}{"id": subnet["id"]},
+			},
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		nic := instance["primary_network_interface"].(map[string]interface{})
+		Expect(nic["primary_ip"].(map[string]interface{})["address"]).To(Equal("10.0.1.4"))
+		Expect(instance["vpc"].(map[string]interface{})["id"]).To(Equal(vpc["id"]))
+
+		res, body := call(http.MethodGet, fmt.Sprintf("/subnets?vpc.id=%s", vpc["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(body["subnets"]).To(HaveLen(1))
+
+		res, _ = call(http.MethodDelete, fmt.Sprintf("/subnets/%s", subnet["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusConflict))
+		res, _ = call(http.MethodDelete, fmt.Sprintf("/vpcs/%s", vpc["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusConflict))
+		res, _ = call(http.MethodDelete, fmt.Sprintf("/security_groups/%s", vpc["default_security_group"].(map[string]interface{})["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusConflict))
+
+		res, _ = call(http.MethodDelete, fmt.Sprintf("/instances/%s", instance["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+		res, _ = call(http.MethodDelete, fmt.Sprintf("/subnets/%s", subnet["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+		res, _ = call(http.MethodDelete, fmt.Sprintf("/vpcs/%s", vpc["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNoContent))
+	})
+	It(`Orders network ACL rules with before`, func() {
+		vpc := createVPC("my-vpc")
+		aclID := vpc["default_network_acl"].(map[string]interface{})["id"]
+		rulesPath := fmt.Sprintf("/network_acls/%s/rules", aclID)
+
+		res, body := call(http.MethodGet, rulesPath, nil)
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		rules := body["rules"].([]interface{})
+		Expect(rules).To(HaveLen(2))
+		first := rules[0].(map[string]interface{})
+
+		res, rule := call(http.MethodPost, rulesPath, map[string]interface{}{
+			"name": "deny-ssh", "action": "deny", "direction": "inbound", "protocol": "tcp",
+			"source": "0.0.0.0/0", "destination": "0.0.0.0/0", "destination_port_min": 22, "destination_port_max": 22,
+			"before": map[string]interface{}{"id": first["id"]},
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		Expect(rule["before"].(map[string]interface{})["id"]).To(Equal(first["id"]))
+
+		res, _ = call(http.MethodPatch, fmt.Sprintf("%s/%s", rulesPath, rule["id"]), map[string]interface{}{"before": nil})
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		_, body = call(http.MethodGet, rulesPath, nil)
+		var names []interface{}
+		for _, r := range body["rules"].([]interface{}) {
+			names = append(names, r.(map[string]interface{})["name"])
+		}
+		Expect(names).To(Equal([]interface{}{"allow-inbound", "allow-outbound", "deny-ssh"}))
+
+		_, acl := call(http.MethodGet, fmt.Sprintf("/network_acls/%s", aclID), nil)
+		Expect(acl["rules"]).To(HaveLen(3))
+	})
+	It(`Transitions snapshots and shares`, func() {
+		server.TransitionReads = 2
+		_, volume := call(http.MethodPost, "/volumes", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "general-purpose"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+		})
+		res, snapshot := call(http.MethodPost, "/snapshots", map[string]interface{}{
+			"source_volume": map[string]interface{}{"id": volume["id"]},
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		path := fmt.Sprintf("/snapshots/%s", snapshot["id"])
+		_, snapshot = call(http.MethodGet, path, nil)
+		Expect(snapshot["lifecycle_state"]).To(Equal("pending"))
+		_, snapshot = call(http.MethodGet, path, nil)
+		Expect(snapshot["lifecycle_state"]).To(Equal("stable"))
+
+		res, share := call(http.MethodPost, "/shares", map[string]interface{}{
+			"profile": map[string]interface{}{"name": "dp2"},
+			"zone":    map[string]interface{}{"name": "us-south-1"},
+			"size":    200,
+		})
+		Expect(res.StatusCode).To(Equal(http.StatusCreated))
+		res, share = call(http.MethodDelete, fmt.Sprintf("/shares/%s", share["id"]), nil)
+		Expect(res.StatusCode).To(Equal(http.StatusAccepted))
+		Expect(share["lifecycle_state"]).To(Equal("deleting"))
+	})
+	It(`Rejects invalid requests`, func() {
+		res, err := http.Get(server.ServiceURL() + "/vpcs")
+		Expect(err).To(BeNil())
+		res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+
+		res, _ = call(http.MethodGet, "/unknown", nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+		res, _ = call(http.MethodPost, "/keys", map[string]interface{}{"name": "my-key"})
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+		res, _ = call(http.MethodGet, "/security_groups/unknown/rules", nil)
+		Expect(res.StatusCode).To(Equal(http.StatusNotFound))
+		res, _ = call(http.MethodPut, "/keys", nil)
+		Expect(res.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
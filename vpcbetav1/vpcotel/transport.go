@@ -0,0 +1,240 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcotel instruments the requests of the vpcbetav1 package with OpenTelemetry
+// traces and metrics. It is opt-in: the instrumentation is a transport configured on the
+// service's HTTP client.
+//
+// Every request gets a client span named after its operation (for example
+// `vpc.create_instance`, see vpcbetav1.OperationID) with the HTTP method and status, the
+// resource ID, the region and the `X-Request-Id` of the request as attributes, and the span
+// context is propagated to the API with the W3C `traceparent` header. The following metrics
+// are recorded, with the operation and status code as attributes:
+//
+//	vpc.client.requests  the number of requests
+//	vpc.client.errors    the number of failed requests (transport errors and 4xx/5xx responses)
+//	vpc.client.duration  the duration of requests, in seconds
+//
+// Example:
+//
+//	transport, err := vpcotel.NewTransport(nil, &vpcotel.Options{TracerProvider: tracerProvider})
+//	vpcService.Service.SetHTTPClient(&http.Client{Transport: transport})
+package vpcotel
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IBM/vpc-beta-go-sdk/common"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter used by the transport.
+const InstrumentationName = "github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcotel"
+
+// The attributes set on spans and metrics, in addition to the semantic conventions.
+const (
+	OperationKey  = attribute.Key("vpc.operation")
+	ResourceIDKey = attribute.Key("vpc.resource_id")
+	RequestIDKey  = attribute.Key("vpc.request_id")
+)
+
+// endpointSuffix is the suffix of the host names of the regional API endpoints.
+const endpointSuffix = ".iaas.cloud.ibm.com"
+
+// Options : The Options struct configures a Transport.
+type Options struct {
+	// The tracer provider. If nil, the global tracer provider is used.
+	TracerProvider trace.TracerProvider
+
+	// The meter provider. If nil, the global meter provider is used.
+	MeterProvider metric.MeterProvider
+
+	// The propagator used to inject the span context into requests. If nil, the W3C trace
+	// context propagator is used.
+	Propagator propagation.TextMapPropagator
+
+	// The region of the service. If not set, it is derived from the host name of regional
+	// endpoints such as `us-south.iaas.cloud.ibm.com`.
+	Region string
+}
+
+// Transport : An http.RoundTripper that instruments requests with OpenTelemetry.
+type Transport struct {
+	// The base transport. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	region     string
+	requests   instrument.Int64Counter
+	errors     instrument.Int64Counter
+	duration   instrument.Float64Histogram
+}
+
+// NewTransport returns a new Transport wrapping the given transport.
+func NewTransport(transport http.RoundTripper, options *Options) (instrumented *Transport, err error) {
+	if options == nil {
+		options = &Options{}
+	}
+	tracerProvider := options.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	meterProvider := options.MeterProvider
+	if meterProvider == nil {
+		meterProvider = global.MeterProvider()
+	}
+	propagator := options.Propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+
+	instrumented = &Transport{
+		Transport:  transport,
+		tracer:     tracerProvider.Tracer(InstrumentationName),
+		propagator: propagator,
+		region:     options.Region,
+	}
+	meter := meterProvider.Meter(InstrumentationName)
+	if instrumented.requests, err = meter.Int64Counter("vpc.client.requests",
+		instrument.WithDescription("The number of requests to the VPC API.")); err != nil {
+		return nil, err
+	}
+	if instrumented.errors, err = meter.Int64Counter("vpc.client.errors",
+		instrument.WithDescription("The number of failed requests to the VPC API.")); err != nil {
+		return nil, err
+	}
+	if instrumented.duration, err = meter.Float64Histogram("vpc.client.duration",
+		instrument.WithDescription("The duration of requests to the VPC API."), instrument.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// RoundTrip sends the request within a span.
+func (transport *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := transport.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	operationID := vpcbetav1.OperationID(req.Method, req.URL.Path)
+	region := transport.region
+	if region == "" && strings.HasSuffix(req.URL.Hostname(), endpointSuffix) {
+		region = strings.Split(req.URL.Hostname(), ".")[0]
+	}
+
+	attributes := []attribute.KeyValue{
+		OperationKey.String(operationID),
+		semconv.HTTPMethodKey.String(req.Method),
+		semconv.NetPeerNameKey.String(req.URL.Hostname()),
+	}
+	if region != "" {
+		attributes = append(attributes, semconv.CloudRegionKey.String(region))
+	}
+	if requestID := req.Header.Get(common.X_REQUEST_ID); requestID != "" {
+		attributes = append(attributes, RequestIDKey.String(requestID))
+	}
+	if resourceID := pathResourceID(req.URL.Path); resourceID != "" {
+		attributes = append(attributes, ResourceIDKey.String(resourceID))
+	}
+
+	ctx, span := transport.tracer.Start(req.Context(), "vpc."+operationID,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	defer span.End()
+
+	// A RoundTripper must not modify the request, so the header is set on a copy.
+	req = req.Clone(ctx)
+	transport.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	res, err := base.RoundTrip(req)
+	metricAttributes := []attribute.KeyValue{OperationKey.String(operationID)}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(res.StatusCode))
+		metricAttributes = append(metricAttributes, semconv.HTTPStatusCodeKey.Int(res.StatusCode))
+		if res.StatusCode >= 400 {
+			span.SetStatus(codes.Error, res.Status)
+		} else if req.Method == http.MethodPost {
+			if resourceID := responseResourceID(res); resourceID != "" {
+				span.SetAttributes(ResourceIDKey.String(resourceID))
+			}
+		}
+	}
+
+	transport.duration.Record(ctx, time.Since(start).Seconds(), metricAttributes...)
+	transport.requests.Add(ctx, 1, metricAttributes...)
+	if err != nil || res.StatusCode >= 400 {
+		transport.errors.Add(ctx, 1, metricAttributes...)
+	}
+	return res, err
+}
+
+// pathResourceID returns the ID of the resource addressed by a path, if any: the last
+// segment of paths made of collection and ID pairs, such as `/v1/vpcs/{id}` (see
+// vpcbetav1.PathSegments).
+func pathResourceID(path string) string {
+	segments := vpcbetav1.PathSegments(path)
+	if len(segments) == 0 || len(segments)%2 != 0 {
+		return ""
+	}
+	return segments[len(segments)-1]
+}
+
+// responseResourceID returns the ID of the resource created by a request, from the body of
+// its response, which is buffered so that it can still be read.
+func responseResourceID(res *http.Response) string {
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), "application/json") {
+		return ""
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		res.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), &errorReader{err}))
+		return ""
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	var result struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(body, &result)
+	return result.ID
+}
+
+// errorReader is a reader that fails with an error.
+type errorReader struct {
+	err error
+}
+
+func (reader *errorReader) Read(p []byte) (int, error) {
+	return 0, reader.err
+}
@@ -0,0 +1,185 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcotel_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/common"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcfake"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1/vpcotel"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerRecorder records the headers of the requests it sends.
+type headerRecorder struct {
+	headers []http.Header
+}
+
+func (recorder *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder.headers = append(recorder.headers, req.Header.Clone())
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// spanAttributes returns the attributes of a span as a map.
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attributes := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attributes[kv.Key] = kv.Value
+	}
+	return attributes
+}
+
+var _ = Describe(`Transport`, func() {
+	var server *vpcfake.Server
+	var service *core.BaseService
+	var exporter *tracetest.InMemoryExporter
+	var tracerProvider *sdktrace.TracerProvider
+	var reader sdkmetric.Reader
+	var recorder *headerRecorder
+
+	send := func(ctx context.Context, method string, path string, body interface{}) (map[string]interface{}, *core.DetailedResponse, error) {
+		builder := core.NewRequestBuilder(method).WithContext(ctx)
+		_, err := builder.ResolveRequestURL(service.Options.URL, path, nil)
+		Expect(err).To(BeNil())
+		for headerName, headerValue := range common.GetSdkHeaders("vpc", "V1", "test_operation") {
+			builder.AddHeader(headerName, headerValue)
+		}
+		builder.AddHeader("Accept", "application/json")
+		builder.AddQuery("version", "2024-03-12")
+		if body != nil {
+			_, err = builder.SetBodyContentJSON(body)
+			Expect(err).To(BeNil())
+		}
+		request, err := builder.Build()
+		Expect(err).To(BeNil())
+		var result map[string]interface{}
+		response, err := service.Request(request, &result)
+		return result, response, err
+	}
+
+	BeforeEach(func() {
+		server = vpcfake.NewServer()
+		var err error
+		service, err = core.NewBaseService(&core.ServiceOptions{
+			URL:           server.ServiceURL(),
+			Authenticator: &core.NoAuthAuthenticator{},
+		})
+		Expect(err).To(BeNil())
+
+		exporter = tracetest.NewInMemoryExporter()
+		tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+		reader = sdkmetric.NewManualReader()
+		recorder = &headerRecorder{}
+		transport, err := vpcotel.NewTransport(recorder, &vpcotel.Options{
+			TracerProvider: tracerProvider,
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+			Region:         "us-south",
+		})
+		Expect(err).To(BeNil())
+		service.SetHTTPClient(&http.Client{Transport: transport})
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It(`Emits a span per operation and propagates the trace context`, func() {
+		ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+		vpc, response, err := send(ctx, core.POST, "/vpcs", map[string]interface{}{"name": "my-vpc"})
+		Expect(err).To(BeNil())
+		_, _, err = send(ctx, core.GET, "/vpcs/"+vpc["id"].(string), nil)
+		Expect(err).To(BeNil())
+		parent.End()
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(3))
+		create, get := spans[0], spans[1]
+		Expect(create.Name).To(Equal("vpc.create_vpc"))
+		Expect(create.SpanKind).To(Equal(trace.SpanKindClient))
+		Expect(create.Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		attributes := spanAttributes(create)
+		Expect(attributes["http.method"].AsString()).To(Equal("POST"))
+		Expect(attributes["http.status_code"].AsInt64()).To(Equal(int64(http.StatusCreated)))
+		Expect(attributes["vpc.resource_id"].AsString()).To(Equal(vpc["id"]))
+		Expect(attributes["cloud.region"].AsString()).To(Equal("us-south"))
+		Expect(attributes["vpc.request_id"].AsString()).To(Equal(response.Headers.Get("X-Request-Id")))
+
+		Expect(get.Name).To(Equal("vpc.get_vpc"))
+		Expect(spanAttributes(get)["vpc.resource_id"].AsString()).To(Equal(vpc["id"]))
+
+		Expect(recorder.headers).To(HaveLen(2))
+		traceparent := recorder.headers[0].Get("traceparent")
+		Expect(traceparent).To(ContainSubstring(create.SpanContext.TraceID().String()))
+		Expect(traceparent).To(ContainSubstring(create.SpanContext.SpanID().String()))
+	})
+	It(`Names the resources of collections with a singular prefix`, func() {
+		send(context.Background(), core.GET, "/instance/profiles", nil)
+		send(context.Background(), core.GET, "/instance/profiles/bx2-2x8", nil)
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Name).To(Equal("vpc.list_instance_profiles"))
+		Expect(spanAttributes(spans[0])).ToNot(HaveKey(attribute.Key("vpc.resource_id")))
+		Expect(spans[1].Name).To(Equal("vpc.get_instance_profile"))
+		Expect(spanAttributes(spans[1])["vpc.resource_id"].AsString()).To(Equal("bx2-2x8"))
+	})
+	It(`Records errors and metrics`, func() {
+		_, _, err := send(context.Background(), core.GET, "/vpcs", nil)
+		Expect(err).To(BeNil())
+		_, response, err := send(context.Background(), core.GET, "/vpcs/missing", nil)
+		Expect(err).ToNot(BeNil())
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Status.Code).To(Equal(codes.Unset))
+		Expect(spans[1].Status.Code).To(Equal(codes.Error))
+
+		var metrics metricdata.ResourceMetrics
+		Expect(reader.Collect(context.Background(), &metrics)).To(Succeed())
+		totals := map[string]int64{}
+		for _, scope := range metrics.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				switch data := m.Data.(type) {
+				case metricdata.Sum[int64]:
+					for _, point := range data.DataPoints {
+						totals[m.Name] += point.Value
+					}
+				case metricdata.Histogram:
+					for _, point := range data.DataPoints {
+						totals[m.Name] += int64(point.Count)
+					}
+				}
+			}
+		}
+		Expect(totals).To(Equal(map[string]int64{
+			"vpc.client.requests": 2,
+			"vpc.client.errors":   1,
+			"vpc.client.duration": 2,
+		}))
+	})
+})
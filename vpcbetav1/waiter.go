@@ -0,0 +1,293 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// Default settings used by WaitForStatus and WaitForDeletion when the corresponding
+// WaiterOptions field is not set.
+const (
+	DefaultWaiterMinDelay   = 2 * time.Second
+	DefaultWaiterMaxDelay   = 60 * time.Second
+	DefaultWaiterMultiplier = 2.0
+	DefaultWaiterJitter     = 0.2
+	DefaultWaiterTimeout    = 30 * time.Minute
+)
+
+// Well-known lifecycle values reported by the VPC API in the `status`, `lifecycle_state`
+// or `provisioning_status` properties of the resources that are typically waited on.
+const (
+	WaiterStatusAvailable = "available"
+	WaiterStatusDeleting  = "deleting"
+	WaiterStatusFailed    = "failed"
+	WaiterStatusPending   = "pending"
+	WaiterStatusRunning   = "running"
+	WaiterStatusStable    = "stable"
+	WaiterStatusStopped   = "stopped"
+	WaiterStatusAttached  = "attached"
+	WaiterStatusDetaching = "detaching"
+	WaiterStatusUpdating  = "updating"
+)
+
+// ErrResourceGone is returned by WaitForStatus when the resource being waited on
+// disappears (the refresh function reports a 404 Not Found).
+var ErrResourceGone = errors.New("resource no longer exists")
+
+// WaitRefreshFunc retrieves the current lifecycle status of a resource.
+// It is usually a thin wrapper around one of the generated Get...WithContext operations,
+// returning the resource's `status`, `lifecycle_state` or `provisioning_status` together
+// with the detailed response of the call.
+type WaitRefreshFunc func(ctx context.Context) (status string, response *core.DetailedResponse, err error)
+
+// WaitProgress describes a single polling attempt and is passed to WaiterOptions.OnProgress.
+type WaitProgress struct {
+	// The 1-based number of the polling attempt.
+	Attempt int
+
+	// The status reported by the refresh function for this attempt.
+	Status string
+
+	// The time elapsed since the wait started.
+	Elapsed time.Duration
+
+	// The delay before the next polling attempt.
+	NextDelay time.Duration
+}
+
+// WaiterOptions : The WaiterOptions struct configures WaitForStatus and WaitForDeletion.
+type WaiterOptions struct {
+	// The statuses that end the wait successfully (ignored by WaitForDeletion).
+	Target []string
+
+	// The statuses that end the wait with an error.
+	// If not set, `failed` and `deleting` are used, minus any status listed in Target.
+	Failure []string
+
+	// The maximum time to wait. The context deadline, if earlier, takes precedence.
+	Timeout time.Duration
+
+	// The delay before the second polling attempt.
+	MinDelay time.Duration
+
+	// The upper bound of the delay between two polling attempts.
+	MaxDelay time.Duration
+
+	// The factor applied to the delay after each polling attempt.
+	Multiplier float64
+
+	// The fraction (0 to 1) of the delay randomly added or removed on each polling attempt.
+	// A negative value disables jitter.
+	Jitter float64
+
+	// Invoked after each polling attempt.
+	OnProgress func(WaitProgress)
+}
+
+// WaitError : The error returned when a wait ends without the resource reaching a target status.
+type WaitError struct {
+	// The last status reported by the refresh function.
+	Status string
+
+	// The number of polling attempts made.
+	Attempts int
+
+	// The underlying cause.
+	Err error
+}
+
+// Error returns the error message.
+func (waitErr *WaitError) Error() string {
+	if waitErr.Status == "" {
+		return fmt.Sprintf("wait failed after %d attempt(s): %s", waitErr.Attempts, waitErr.Err)
+	}
+	return fmt.Sprintf("wait failed after %d attempt(s) with status '%s': %s", waitErr.Attempts, waitErr.Status, waitErr.Err)
+}
+
+// Unwrap returns the underlying cause.
+func (waitErr *WaitError) Unwrap() error {
+	return waitErr.Err
+}
+
+// WaitForStatus polls refresh until it reports one of options.Target, using an exponential
+// backoff with jitter between attempts.
+// The wait ends with an error when the context is done, the timeout expires, refresh fails,
+// the resource reaches one of options.Failure, or the resource is gone (ErrResourceGone).
+func WaitForStatus(ctx context.Context, refresh WaitRefreshFunc, options *WaiterOptions) (status string, err error) {
+	if refresh == nil {
+		err = fmt.Errorf("refresh function cannot be nil")
+		return
+	}
+	if options == nil || len(options.Target) == 0 {
+		err = fmt.Errorf("at least one target status must be specified")
+		return
+	}
+	failure := options.Failure
+	if failure == nil {
+		for _, s := range []string{WaiterStatusFailed, WaiterStatusDeleting} {
+			if !core.SliceContains(options.Target, s) {
+				failure = append(failure, s)
+			}
+		}
+	}
+
+	status, err = wait(ctx, options, refresh, func(current string, response *core.DetailedResponse, refreshErr error) (bool, error) {
+		if refreshErr != nil {
+			if response != nil && response.StatusCode == http.StatusNotFound {
+				return true, ErrResourceGone
+			}
+			return true, refreshErr
+		}
+		if core.SliceContains(options.Target, current) {
+			return true, nil
+		}
+		if core.SliceContains(failure, current) {
+			return true, fmt.Errorf("resource reached terminal status '%s'", current)
+		}
+		return false, nil
+	})
+	return
+}
+
+// WaitForDeletion polls refresh until it reports that the resource no longer exists
+// (404 Not Found), using the same backoff as WaitForStatus.
+// Statuses listed in options.Failure end the wait with an error; options.Target is ignored.
+func WaitForDeletion(ctx context.Context, refresh WaitRefreshFunc, options *WaiterOptions) (err error) {
+	if refresh == nil {
+		return fmt.Errorf("refresh function cannot be nil")
+	}
+	if options == nil {
+		options = &WaiterOptions{}
+	}
+	failure := options.Failure
+	if failure == nil {
+		failure = []string{WaiterStatusFailed}
+	}
+
+	_, err = wait(ctx, options, refresh, func(current string, response *core.DetailedResponse, refreshErr error) (bool, error) {
+		if refreshErr != nil {
+			if response != nil && response.StatusCode == http.StatusNotFound {
+				return true, nil
+			}
+			return true, refreshErr
+		}
+		if core.SliceContains(failure, current) {
+			return true, fmt.Errorf("resource reached terminal status '%s'", current)
+		}
+		return false, nil
+	})
+	return
+}
+
+// wait drives the polling loop shared by WaitForStatus and WaitForDeletion.
+// The check function decides, for each attempt, whether the wait is over and with which result.
+func wait(ctx context.Context, options *WaiterOptions, refresh WaitRefreshFunc,
+	check func(status string, response *core.DetailedResponse, err error) (bool, error)) (status string, err error) {
+	timeout := options.Timeout
+	if timeout <= 0 {
+		timeout = DefaultWaiterTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		current, response, refreshErr := refresh(ctx)
+		if refreshErr == nil {
+			status = current
+		} else if ctxErr := ctx.Err(); ctxErr != nil {
+			// The refresh was most likely aborted by the context; report that instead.
+			err = &WaitError{Status: status, Attempts: attempt, Err: ctxErr}
+			return
+		}
+
+		done, checkErr := check(current, response, refreshErr)
+		if done {
+			if checkErr != nil {
+				err = &WaitError{Status: status, Attempts: attempt, Err: checkErr}
+			}
+			return
+		}
+
+		delay := backoffDelay(options, attempt)
+		if options.OnProgress != nil {
+			options.OnProgress(WaitProgress{
+				Attempt:   attempt,
+				Status:    current,
+				Elapsed:   time.Since(start),
+				NextDelay: delay,
+			})
+		}
+
+		if sleepErr := sleepContext(ctx, delay); sleepErr != nil {
+			err = &WaitError{Status: status, Attempts: attempt, Err: sleepErr}
+			return
+		}
+	}
+}
+
+// sleepContext waits for the given delay, or until the context is done.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoffDelay returns the delay to apply after the given (1-based) attempt.
+func backoffDelay(options *WaiterOptions, attempt int) time.Duration {
+	minDelay := options.MinDelay
+	if minDelay <= 0 {
+		minDelay = DefaultWaiterMinDelay
+	}
+	maxDelay := options.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultWaiterMaxDelay
+	}
+	multiplier := options.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultWaiterMultiplier
+	}
+	jitter := options.Jitter
+	if jitter == 0 {
+		jitter = DefaultWaiterJitter
+	} else if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
+	}
+
+	delay := float64(minDelay) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+	delay += delay * jitter * (2*rand.Float64() - 1) // #nosec G404
+	return time.Duration(delay)
+}
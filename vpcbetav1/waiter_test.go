@@ -0,0 +1,131 @@
+/**
+ * (C) Copyright IBM Corp. 2026.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vpcbetav1_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-beta-go-sdk/vpcbetav1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// statusSequence returns a refresh function that reports the given statuses in order,
+// repeating the last one once the sequence is exhausted.
+func statusSequence(statuses ...string) (vpcbetav1.WaitRefreshFunc, *int) {
+	calls := 0
+	return func(ctx context.Context) (string, *core.DetailedResponse, error) {
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		return status, &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+	}, &calls
+}
+
+var _ = Describe(`Waiter`, func() {
+	fastOptions := func(target ...string) *vpcbetav1.WaiterOptions {
+		return &vpcbetav1.WaiterOptions{
+			Target:   target,
+			MinDelay: time.Millisecond,
+			MaxDelay: 5 * time.Millisecond,
+			Jitter:   -1,
+		}
+	}
+
+	Describe(`WaitForStatus`, func() {
+		It(`Returns once a target status is reached`, func() {
+			refresh, calls := statusSequence("pending", "pending", "running")
+			var progress []vpcbetav1.WaitProgress
+			options := fastOptions(vpcbetav1.WaiterStatusRunning)
+			options.OnProgress = func(p vpcbetav1.WaitProgress) {
+				progress = append(progress, p)
+			}
+
+			status, err := vpcbetav1.WaitForStatus(context.Background(), refresh, options)
+			Expect(err).To(BeNil())
+			Expect(status).To(Equal("running"))
+			Expect(*calls).To(Equal(3))
+			Expect(progress).To(HaveLen(2))
+			Expect(progress[0].Attempt).To(Equal(1))
+			Expect(progress[0].Status).To(Equal("pending"))
+			Expect(progress[1].NextDelay).To(Equal(2 * time.Millisecond))
+		})
+		It(`Fails on a terminal status`, func() {
+			refresh, _ := statusSequence("pending", "failed")
+			status, err := vpcbetav1.WaitForStatus(context.Background(), refresh, fastOptions("available"))
+			Expect(status).To(Equal("failed"))
+			var waitErr *vpcbetav1.WaitError
+			Expect(errors.As(err, &waitErr)).To(BeTrue())
+			Expect(waitErr.Attempts).To(Equal(2))
+		})
+		It(`Reports a resource that disappeared`, func() {
+			refresh := func(ctx context.Context) (string, *core.DetailedResponse, error) {
+				return "", &core.DetailedResponse{StatusCode: http.StatusNotFound}, errors.New("not found")
+			}
+			_, err := vpcbetav1.WaitForStatus(context.Background(), refresh, fastOptions("available"))
+			Expect(errors.Is(err, vpcbetav1.ErrResourceGone)).To(BeTrue())
+		})
+		It(`Stops when the context is cancelled`, func() {
+			refresh, _ := statusSequence("pending")
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			_, err := vpcbetav1.WaitForStatus(ctx, refresh, fastOptions("available"))
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		})
+		It(`Stops when the timeout expires`, func() {
+			refresh, _ := statusSequence("pending")
+			options := fastOptions("available")
+			options.Timeout = 20 * time.Millisecond
+			_, err := vpcbetav1.WaitForStatus(context.Background(), refresh, options)
+			Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		})
+		It(`Invoke WaitForStatus with error`, func() {
+			refresh, _ := statusSequence("pending")
+			_, err := vpcbetav1.WaitForStatus(context.Background(), refresh, nil)
+			Expect(err).ToNot(BeNil())
+			_, err = vpcbetav1.WaitForStatus(context.Background(), nil, fastOptions("available"))
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	Describe(`WaitForDeletion`, func() {
+		It(`Returns once the resource is gone`, func() {
+			calls := 0
+			refresh := func(ctx context.Context) (string, *core.DetailedResponse, error) {
+				calls++
+				if calls < 3 {
+					return "deleting", &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+				}
+				return "", &core.DetailedResponse{StatusCode: http.StatusNotFound}, errors.New("not found")
+			}
+			err := vpcbetav1.WaitForDeletion(context.Background(), refresh, fastOptions())
+			Expect(err).To(BeNil())
+			Expect(calls).To(Equal(3))
+		})
+		It(`Fails on a terminal status`, func() {
+			refresh, _ := statusSequence("deleting", "failed")
+			err := vpcbetav1.WaitForDeletion(context.Background(), refresh, fastOptions())
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})